@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"go.kirha.ai/neo4go"
+)
+
+func newBaselineCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "baseline <version>",
+		Short: "Record migrations up to a version as applied without running them",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid version number: %w", err)
+			}
+
+			cfg, err := opts.config()
+			if err != nil {
+				return err
+			}
+
+			migrator, err := neo4go.New(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create migrator: %w", err)
+			}
+			defer func() {
+				_ = migrator.Close()
+			}()
+
+			baselined, err := migrator.Baseline(cmd.Context(), version)
+			if err != nil {
+				return fmt.Errorf("failed to baseline at version %d: %w", version, err)
+			}
+
+			if baselined == nil {
+				baselined = []int{}
+			}
+
+			return opts.print(baselineOutput{Version: version, Baselined: baselined}, func() {
+				if len(baselined) == 0 {
+					fmt.Printf("All migrations up to version %d are already applied\n", version)
+					return
+				}
+
+				for _, baselinedVersion := range baselined {
+					fmt.Printf("Baselined version %d\n", baselinedVersion)
+				}
+			})
+		},
+	}
+}
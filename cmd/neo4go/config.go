@@ -3,41 +3,185 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
 	"go.kirha.ai/neo4go"
+	"gopkg.in/yaml.v3"
 )
 
-func getConfigFromEnv() (neo4go.Config, error) {
-	uri := os.Getenv("NEO4J_URI")
-	if uri == "" {
-		return neo4go.Config{}, fmt.Errorf("NEO4J_URI environment variable is required")
+var defaultConfigFiles = []string{"neo4go.yaml", "neo4go.yml", "neo4go.toml"}
+
+type settings struct {
+	URI           string `yaml:"uri" toml:"uri"`
+	Username      string `yaml:"username" toml:"username"`
+	Password      string `yaml:"password" toml:"password"`
+	Database      string `yaml:"database" toml:"database"`
+	MigrationsDir string `yaml:"migrations_dir" toml:"migrations_dir"`
+}
+
+type configFile struct {
+	settings     `yaml:",inline"`
+	Environments map[string]settings `yaml:"environments" toml:"environments"`
+}
+
+type options struct {
+	configPath string
+	env        string
+	output     string
+	logLevel   string
+	logFormat  string
+	flags      settings
+}
+
+func (o *options) register(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&o.configPath, "config", "", "config file (default: neo4go.yaml, neo4go.yml or neo4go.toml in the current directory)")
+	flags.StringVar(&o.env, "env", "", "named environment from the config file (env: NEO4GO_ENV)")
+	flags.StringVar(&o.flags.URI, "uri", "", "Neo4j connection URI (env: NEO4J_URI)")
+	flags.StringVar(&o.flags.Username, "username", "", "Neo4j username (env: NEO4J_USERNAME)")
+	flags.StringVar(&o.flags.Password, "password", "", "Neo4j password; visible in shell history and ps, prefer NEO4J_PASSWORD or the config file")
+	flags.StringVar(&o.flags.Database, "database", "", "Neo4j database (env: NEO4J_DATABASE, default: neo4j)")
+	flags.StringVar(&o.flags.MigrationsDir, "dir", "", "migrations directory (env: NEO4J_MIGRATIONS_DIR, default: ./migrations)")
+	flags.StringVarP(&o.output, "output", "o", outputTable, "output format: table, json or yaml")
+	flags.StringVar(&o.logLevel, "log-level", "info", "log level: debug, info, warn or error")
+	flags.StringVar(&o.logFormat, "log-format", logFormatText, "log format: text or json")
+}
+
+func (o *options) settings() (settings, error) {
+	var resolved settings
+
+	file, err := o.loadFile()
+	if err != nil {
+		return settings{}, err
+	}
+
+	resolved.merge(file)
+	resolved.merge(settingsFromEnv())
+	resolved.merge(o.flags)
+
+	if resolved.Database == "" {
+		resolved.Database = "neo4j"
+	}
+
+	if resolved.MigrationsDir == "" {
+		resolved.MigrationsDir = "./migrations"
 	}
 
-	username := os.Getenv("NEO4J_USERNAME")
-	if username == "" {
-		return neo4go.Config{}, fmt.Errorf("NEO4J_USERNAME environment variable is required")
+	return resolved, nil
+}
+
+func (o *options) config() (neo4go.Config, error) {
+	resolved, err := o.settings()
+	if err != nil {
+		return neo4go.Config{}, err
 	}
 
-	password := os.Getenv("NEO4J_PASSWORD")
-	if password == "" {
-		return neo4go.Config{}, fmt.Errorf("NEO4J_PASSWORD environment variable is required")
+	if resolved.URI == "" {
+		return neo4go.Config{}, fmt.Errorf("neo4j URI is required (--uri, NEO4J_URI or config file)")
 	}
 
-	database := os.Getenv("NEO4J_DATABASE")
-	if database == "" {
-		database = "neo4j"
+	if resolved.Username == "" {
+		return neo4go.Config{}, fmt.Errorf("neo4j username is required (--username, NEO4J_USERNAME or config file)")
 	}
 
-	migrationsDir := os.Getenv("NEO4J_MIGRATIONS_DIR")
-	if migrationsDir == "" {
-		migrationsDir = "./migrations"
+	if resolved.Password == "" {
+		return neo4go.Config{}, fmt.Errorf("neo4j password is required (--password, NEO4J_PASSWORD or config file)")
+	}
+
+	logger, err := o.logger(os.Stderr)
+	if err != nil {
+		return neo4go.Config{}, err
 	}
 
 	return neo4go.Config{
-		URI:           uri,
-		Username:      username,
-		Password:      password,
-		Database:      database,
-		MigrationsDir: migrationsDir,
+		URI:           resolved.URI,
+		Username:      resolved.Username,
+		Password:      resolved.Password,
+		Database:      resolved.Database,
+		MigrationsDir: resolved.MigrationsDir,
+		Logger:        logger,
 	}, nil
 }
+
+func (o *options) loadFile() (settings, error) {
+	env := o.env
+	if env == "" {
+		env = os.Getenv("NEO4GO_ENV")
+	}
+
+	path := o.configPath
+	if path == "" {
+		for _, candidate := range defaultConfigFiles {
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+	}
+
+	if path == "" {
+		if env != "" {
+			return settings{}, fmt.Errorf("environment %q requested but no config file found", env)
+		}
+		return settings{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return settings{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var file configFile
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	case ".toml":
+		err = toml.Unmarshal(data, &file)
+	default:
+		return settings{}, fmt.Errorf("unsupported config file format %q (use .yaml, .yml or .toml)", path)
+	}
+	if err != nil {
+		return settings{}, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	resolved := file.settings
+	if env != "" {
+		selected, ok := file.Environments[env]
+		if !ok {
+			return settings{}, fmt.Errorf("environment %q not found in %s", env, path)
+		}
+		resolved.merge(selected)
+	}
+
+	return resolved, nil
+}
+
+func settingsFromEnv() settings {
+	return settings{
+		URI:           os.Getenv("NEO4J_URI"),
+		Username:      os.Getenv("NEO4J_USERNAME"),
+		Password:      os.Getenv("NEO4J_PASSWORD"),
+		Database:      os.Getenv("NEO4J_DATABASE"),
+		MigrationsDir: os.Getenv("NEO4J_MIGRATIONS_DIR"),
+	}
+}
+
+func (s *settings) merge(other settings) {
+	if other.URI != "" {
+		s.URI = other.URI
+	}
+	if other.Username != "" {
+		s.Username = other.Username
+	}
+	if other.Password != "" {
+		s.Password = other.Password
+	}
+	if other.Database != "" {
+		s.Database = other.Database
+	}
+	if other.MigrationsDir != "" {
+		s.MigrationsDir = other.MigrationsDir
+	}
+}
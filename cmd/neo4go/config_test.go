@@ -0,0 +1,135 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const yamlConfig = `uri: bolt://file:7687
+username: file-user
+password: file-password
+migrations_dir: ./file-migrations
+environments:
+  prod:
+    uri: bolt://prod:7687
+    database: prod-db
+`
+
+const tomlConfig = `uri = "bolt://file:7687"
+username = "file-user"
+database = "file-db"
+
+[environments.staging]
+uri = "bolt://staging:7687"
+`
+
+func TestOptionsSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		env         map[string]string
+		opts        options
+		expect      settings
+		expectError string
+	}{
+		{
+			name:    "file only",
+			file:    "neo4go.yaml",
+			content: yamlConfig,
+			expect: settings{
+				URI: "bolt://file:7687", Username: "file-user", Password: "file-password",
+				Database: "neo4j", MigrationsDir: "./file-migrations",
+			},
+		},
+		{
+			name:    "flag over env over file",
+			file:    "neo4go.yaml",
+			content: yamlConfig,
+			env:     map[string]string{"NEO4J_URI": "bolt://env:7687", "NEO4J_USERNAME": "env-user"},
+			opts:    options{flags: settings{URI: "bolt://flag:7687"}},
+			expect: settings{
+				URI: "bolt://flag:7687", Username: "env-user", Password: "file-password",
+				Database: "neo4j", MigrationsDir: "./file-migrations",
+			},
+		},
+		{
+			name:    "named environment overrides top-level keys",
+			file:    "neo4go.yaml",
+			content: yamlConfig,
+			opts:    options{env: "prod"},
+			expect: settings{
+				URI: "bolt://prod:7687", Username: "file-user", Password: "file-password",
+				Database: "prod-db", MigrationsDir: "./file-migrations",
+			},
+		},
+		{
+			name:    "environment selected through NEO4GO_ENV",
+			file:    "neo4go.yaml",
+			content: yamlConfig,
+			env:     map[string]string{"NEO4GO_ENV": "prod", "NEO4J_DATABASE": "env-db"},
+			expect: settings{
+				URI: "bolt://prod:7687", Username: "file-user", Password: "file-password",
+				Database: "env-db", MigrationsDir: "./file-migrations",
+			},
+		},
+		{
+			name:        "unknown environment",
+			file:        "neo4go.yaml",
+			content:     yamlConfig,
+			opts:        options{env: "qa"},
+			expectError: `environment "qa" not found`,
+		},
+		{
+			name:    "toml file",
+			file:    "neo4go.toml",
+			content: tomlConfig,
+			opts:    options{env: "staging"},
+			expect: settings{
+				URI: "bolt://staging:7687", Username: "file-user",
+				Database: "file-db", MigrationsDir: "./migrations",
+			},
+		},
+		{
+			name:        "unsupported extension",
+			file:        "neo4go.json",
+			content:     "{}",
+			expectError: "unsupported config file format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"NEO4J_URI", "NEO4J_USERNAME", "NEO4J_PASSWORD", "NEO4J_DATABASE", "NEO4J_MIGRATIONS_DIR", "NEO4GO_ENV"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("failed to write config file: %v", err)
+			}
+
+			opts := tt.opts
+			opts.configPath = path
+
+			resolved, err := opts.settings()
+
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if resolved != tt.expect {
+				t.Errorf("expected %+v, got %+v", tt.expect, resolved)
+			}
+		})
+	}
+}
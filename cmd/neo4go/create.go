@@ -9,18 +9,36 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func newCreateCmd() *cobra.Command {
-	return &cobra.Command{
+const migrationTemplate = `-- +neo4go Up
+-- Add your up migration statements here
+
+
+-- +neo4go Down
+-- Add your down migration statements here
+
+`
+
+const autoDownMigrationTemplate = `-- +neo4go Up
+-- Add named CREATE CONSTRAINT or CREATE INDEX statements here.
+-- The Down section is derived from them; review it with neo4go derive-down.
+
+`
+
+func newCreateCmd(opts *options) *cobra.Command {
+	var autoDown bool
+
+	cmd := &cobra.Command{
 		Use:   "create <name>",
 		Short: "Create a new migration file",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			name := args[0]
 
-			migrationsDir := os.Getenv("NEO4J_MIGRATIONS_DIR")
-			if migrationsDir == "" {
-				migrationsDir = "./migrations"
+			resolved, err := opts.settings()
+			if err != nil {
+				return err
 			}
+			migrationsDir := resolved.MigrationsDir
 
 			if err := os.MkdirAll(migrationsDir, 0750); err != nil {
 				return fmt.Errorf("failed to create migrations directory: %w", err)
@@ -30,21 +48,22 @@ func newCreateCmd() *cobra.Command {
 			filename := fmt.Sprintf("%d_%s.cypher", version, name)
 			filePath := filepath.Join(migrationsDir, filename)
 
-			content := `-- +neo4go Up
--- Add your up migration statements here
-
-
--- +neo4go Down
--- Add your down migration statements here
-
-`
+			content := migrationTemplate
+			if autoDown {
+				content = autoDownMigrationTemplate
+			}
 
 			if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
 				return fmt.Errorf("failed to create migration file: %w", err)
 			}
 
-			fmt.Printf("Created migration: %s\n", filePath)
-			return nil
+			return opts.print(createOutput{Path: filePath}, func() {
+				fmt.Printf("Created migration: %s\n", filePath)
+			})
 		},
 	}
+
+	cmd.Flags().BoolVar(&autoDown, "auto-down", false, "omit the Down section so it is derived from the schema statements in Up")
+
+	return cmd
 }
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.kirha.ai/neo4go"
+)
+
+func newDeriveDownCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "derive-down <file>",
+		Short: "Print the Down section derived from a migration's schema statements",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			content, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read migration file: %w", err)
+			}
+
+			down, err := neo4go.DeriveDown(string(content))
+			if err != nil {
+				return fmt.Errorf("failed to derive down section of %s: %w", args[0], err)
+			}
+
+			return opts.print(deriveDownOutput{Path: args[0], Down: down}, func() {
+				fmt.Println(down)
+			})
+		},
+	}
+}
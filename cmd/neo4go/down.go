@@ -7,13 +7,15 @@ import (
 	"go.kirha.ai/neo4go"
 )
 
-func newDownCmd() *cobra.Command {
-	return &cobra.Command{
+func newDownCmd(opts *options) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
 		Use:   "down",
 		Short: "Rollback the last migration",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			cfg, err := getConfigFromEnv()
+			cfg, err := opts.config()
 			if err != nil {
 				return err
 			}
@@ -26,12 +28,26 @@ func newDownCmd() *cobra.Command {
 				_ = migrator.Close()
 			}()
 
-			if err := migrator.Down(cmd.Context()); err != nil {
-				return fmt.Errorf("failed to rollback migration: %w", err)
+			if dryRun {
+				target, err := previousVersion(cmd.Context(), migrator)
+				if err != nil {
+					return err
+				}
+
+				plan, err := migrator.Plan(cmd.Context(), neo4go.DirectionDown, target)
+				if err != nil {
+					return fmt.Errorf("failed to plan migrations: %w", err)
+				}
+
+				return printPlan(opts, plan)
 			}
 
-			fmt.Println("Migration rolled back successfully")
-			return nil
+			return runMigrations(cmd.Context(), opts, migrator, migrator.DownWithResults,
+				"Migration rolled back successfully", "failed to rollback migration")
 		},
 	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the migrations and statements that would run without executing them")
+
+	return cmd
 }
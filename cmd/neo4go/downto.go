@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -8,8 +9,10 @@ import (
 	"go.kirha.ai/neo4go"
 )
 
-func newDownToCmd() *cobra.Command {
-	return &cobra.Command{
+func newDownToCmd(opts *options) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
 		Use:   "down-to <version>",
 		Short: "Rollback down to a specific version",
 		Args:  cobra.ExactArgs(1),
@@ -19,7 +22,7 @@ func newDownToCmd() *cobra.Command {
 				return fmt.Errorf("invalid version number: %w", err)
 			}
 
-			cfg, err := getConfigFromEnv()
+			cfg, err := opts.config()
 			if err != nil {
 				return err
 			}
@@ -32,12 +35,24 @@ func newDownToCmd() *cobra.Command {
 				_ = migrator.Close()
 			}()
 
-			if err := migrator.DownTo(cmd.Context(), version); err != nil {
-				return fmt.Errorf("failed to rollback to version %d: %w", version, err)
+			if dryRun {
+				plan, err := migrator.Plan(cmd.Context(), neo4go.DirectionDown, version)
+				if err != nil {
+					return fmt.Errorf("failed to plan migrations: %w", err)
+				}
+
+				return printPlan(opts, plan)
 			}
 
-			fmt.Printf("Rolled back to version %d successfully\n", version)
-			return nil
+			downTo := func(ctx context.Context) ([]neo4go.MigrationResult, error) {
+				return migrator.DownToWithResults(ctx, version)
+			}
+			return runMigrations(cmd.Context(), opts, migrator, downTo,
+				fmt.Sprintf("Rolled back to version %d successfully", version), fmt.Sprintf("failed to rollback to version %d", version))
 		},
 	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the migrations and statements that would run without executing them")
+
+	return cmd
 }
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"go.kirha.ai/neo4go"
+)
+
+func newForceCmd(opts *options) *cobra.Command {
+	var applied bool
+
+	cmd := &cobra.Command{
+		Use:   "force <version>",
+		Short: "Mark a version as applied or not applied without running it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid version number: %w", err)
+			}
+
+			cfg, err := opts.config()
+			if err != nil {
+				return err
+			}
+
+			migrator, err := neo4go.New(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create migrator: %w", err)
+			}
+			defer func() {
+				_ = migrator.Close()
+			}()
+
+			if err := migrator.Force(cmd.Context(), version, applied); err != nil {
+				return fmt.Errorf("failed to force version %d: %w", version, err)
+			}
+
+			return opts.print(forceOutput{Version: version, Applied: applied}, func() {
+				if applied {
+					fmt.Printf("Marked version %d as applied\n", version)
+				} else {
+					fmt.Printf("Marked version %d as not applied\n", version)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&applied, "applied", true, "mark the version as applied (false removes its record)")
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.kirha.ai/neo4go"
+)
+
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+func parseLogLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if value == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return 0, fmt.Errorf("unsupported log level %q (use debug, info, warn or error)", value)
+	}
+	return level, nil
+}
+
+func (o *options) validateLogging() error {
+	if _, err := parseLogLevel(o.logLevel); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(o.logFormat) {
+	case "", logFormatText, logFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("unsupported log format %q (use text or json)", o.logFormat)
+	}
+}
+
+func (o *options) logger(w io.Writer) (neo4go.Logger, error) {
+	level, err := parseLogLevel(o.logLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	handlerOptions := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(o.logFormat) {
+	case "", logFormatText:
+		handler = slog.NewTextHandler(w, handlerOptions)
+	case logFormatJSON:
+		handler = slog.NewJSONHandler(w, handlerOptions)
+	default:
+		return nil, fmt.Errorf("unsupported log format %q (use text or json)", o.logFormat)
+	}
+
+	return neo4go.NewSlogLogger(slog.New(handler)), nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestOptionsLogger(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        options
+		expectLines int
+		expectJSON  bool
+		expectError string
+	}{
+		{name: "defaults to info text", opts: options{}, expectLines: 3},
+		{name: "debug level", opts: options{logLevel: "debug"}, expectLines: 4},
+		{name: "error level", opts: options{logLevel: "ERROR"}, expectLines: 1},
+		{name: "json format", opts: options{logLevel: "warn", logFormat: "json"}, expectLines: 2, expectJSON: true},
+		{name: "unknown level", opts: options{logLevel: "verbose"}, expectError: "unsupported log level"},
+		{name: "unknown format", opts: options{logFormat: "xml"}, expectError: "unsupported log format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := tt.opts.logger(&buf)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectError, err)
+				}
+				if err := tt.opts.validateLogging(); err == nil {
+					t.Fatalf("expected validateLogging to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			logger.Debug("debug message")
+			logger.Info("info message", "version", 1)
+			logger.Warn("warn message")
+			logger.Error("error message")
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != tt.expectLines {
+				t.Fatalf("expected %d lines, got %d: %q", tt.expectLines, len(lines), buf.String())
+			}
+
+			for _, line := range lines {
+				var entry map[string]any
+				isJSON := json.Unmarshal([]byte(line), &entry) == nil
+				if isJSON != tt.expectJSON {
+					t.Errorf("expected JSON=%v for line %q", tt.expectJSON, line)
+				}
+			}
+		})
+	}
+}
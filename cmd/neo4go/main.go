@@ -22,13 +22,31 @@ func newRootCmd() *cobra.Command {
 		Long:  "neo4go is a schema migration tool for Neo4j databases",
 	}
 
-	cmd.AddCommand(newUpCmd())
-	cmd.AddCommand(newDownCmd())
-	cmd.AddCommand(newStatusCmd())
-	cmd.AddCommand(newVersionCmd())
-	cmd.AddCommand(newCreateCmd())
-	cmd.AddCommand(newUpToCmd())
-	cmd.AddCommand(newDownToCmd())
+	opts := &options{}
+	opts.register(cmd)
+
+	cmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
+		if err := opts.validateOutput(); err != nil {
+			return err
+		}
+		return opts.validateLogging()
+	}
+
+	cmd.AddCommand(newUpCmd(opts))
+	cmd.AddCommand(newDownCmd(opts))
+	cmd.AddCommand(newStatusCmd(opts))
+	cmd.AddCommand(newVersionCmd(opts))
+	cmd.AddCommand(newCreateCmd(opts))
+	cmd.AddCommand(newUpToCmd(opts))
+	cmd.AddCommand(newDownToCmd(opts))
+	cmd.AddCommand(newRedoCmd(opts))
+	cmd.AddCommand(newResetCmd(opts))
+	cmd.AddCommand(newForceCmd(opts))
+	cmd.AddCommand(newRepairCmd(opts))
+	cmd.AddCommand(newBaselineCmd(opts))
+	cmd.AddCommand(newValidateCmd(opts))
+	cmd.AddCommand(newSchemaCmd(opts))
+	cmd.AddCommand(newDeriveDownCmd(opts))
 
 	return cmd
 }
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"go.kirha.ai/neo4go"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+type statusOutput struct {
+	Version         int        `json:"version" yaml:"version"`
+	Name            string     `json:"name" yaml:"name"`
+	Applied         bool       `json:"applied" yaml:"applied"`
+	AppliedAt       *time.Time `json:"applied_at,omitempty" yaml:"applied_at,omitempty"`
+	Checksum        string     `json:"checksum" yaml:"checksum"`
+	Baselined       bool       `json:"baselined" yaml:"baselined"`
+	Reversible      bool       `json:"reversible" yaml:"reversible"`
+	Dirty           bool       `json:"dirty" yaml:"dirty"`
+	Error           string     `json:"error,omitempty" yaml:"error,omitempty"`
+	FailedStatement int        `json:"failed_statement,omitempty" yaml:"failed_statement,omitempty"`
+}
+
+type versionOutput struct {
+	Version int `json:"version" yaml:"version"`
+}
+
+type migrationOutput struct {
+	Version    int            `json:"version" yaml:"version"`
+	Name       string         `json:"name" yaml:"name"`
+	Direction  string         `json:"direction" yaml:"direction"`
+	DurationMs int64          `json:"duration_ms" yaml:"duration_ms"`
+	Statements int            `json:"statements" yaml:"statements"`
+	Counters   countersOutput `json:"counters" yaml:"counters"`
+	Error      string         `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type countersOutput struct {
+	NodesCreated         int `json:"nodes_created" yaml:"nodes_created"`
+	NodesDeleted         int `json:"nodes_deleted" yaml:"nodes_deleted"`
+	RelationshipsCreated int `json:"relationships_created" yaml:"relationships_created"`
+	RelationshipsDeleted int `json:"relationships_deleted" yaml:"relationships_deleted"`
+	PropertiesSet        int `json:"properties_set" yaml:"properties_set"`
+	LabelsAdded          int `json:"labels_added" yaml:"labels_added"`
+	LabelsRemoved        int `json:"labels_removed" yaml:"labels_removed"`
+	IndexesAdded         int `json:"indexes_added" yaml:"indexes_added"`
+	IndexesRemoved       int `json:"indexes_removed" yaml:"indexes_removed"`
+	ConstraintsAdded     int `json:"constraints_added" yaml:"constraints_added"`
+	ConstraintsRemoved   int `json:"constraints_removed" yaml:"constraints_removed"`
+}
+
+type runOutput struct {
+	Migrations []migrationOutput `json:"migrations" yaml:"migrations"`
+	Version    int               `json:"version" yaml:"version"`
+	DurationMs int64             `json:"duration_ms" yaml:"duration_ms"`
+	Error      string            `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type statementOutput struct {
+	Line int    `json:"line" yaml:"line"`
+	Kind string `json:"kind" yaml:"kind"`
+	Text string `json:"text" yaml:"text"`
+}
+
+type plannedOutput struct {
+	Version     int               `json:"version" yaml:"version"`
+	Name        string            `json:"name" yaml:"name"`
+	Direction   string            `json:"direction" yaml:"direction"`
+	Transaction string            `json:"transaction" yaml:"transaction"`
+	Go          bool              `json:"go" yaml:"go"`
+	Statements  []statementOutput `json:"statements" yaml:"statements"`
+}
+
+type forceOutput struct {
+	Version int  `json:"version" yaml:"version"`
+	Applied bool `json:"applied" yaml:"applied"`
+}
+
+type repairOutput struct {
+	Repaired []int `json:"repaired" yaml:"repaired"`
+}
+
+type baselineOutput struct {
+	Version   int   `json:"version" yaml:"version"`
+	Baselined []int `json:"baselined" yaml:"baselined"`
+}
+
+type issueOutput struct {
+	File     string `json:"file" yaml:"file"`
+	Line     int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Severity string `json:"severity" yaml:"severity"`
+	Rule     string `json:"rule" yaml:"rule"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+type snapshotFile struct {
+	Constraints []schemaObjectOutput `json:"constraints" yaml:"constraints"`
+	Indexes     []schemaObjectOutput `json:"indexes" yaml:"indexes"`
+}
+
+type schemaObjectOutput struct {
+	Name            string   `json:"name" yaml:"name"`
+	Type            string   `json:"type" yaml:"type"`
+	EntityType      string   `json:"entity_type" yaml:"entity_type"`
+	LabelsOrTypes   []string `json:"labels_or_types" yaml:"labels_or_types"`
+	Properties      []string `json:"properties" yaml:"properties"`
+	CreateStatement string   `json:"create_statement" yaml:"create_statement"`
+}
+
+type schemaDumpOutput struct {
+	JSON   string `json:"json" yaml:"json"`
+	Cypher string `json:"cypher" yaml:"cypher"`
+}
+
+type schemaChangeOutput struct {
+	Kind     string `json:"kind" yaml:"kind"`
+	Name     string `json:"name" yaml:"name"`
+	Expected string `json:"expected,omitempty" yaml:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty" yaml:"actual,omitempty"`
+}
+
+type schemaDiffOutput struct {
+	Missing    []schemaChangeOutput `json:"missing" yaml:"missing"`
+	Unexpected []schemaChangeOutput `json:"unexpected" yaml:"unexpected"`
+	Changed    []schemaChangeOutput `json:"changed" yaml:"changed"`
+}
+
+type deriveDownOutput struct {
+	Path string `json:"path" yaml:"path"`
+	Down string `json:"down" yaml:"down"`
+}
+
+type createOutput struct {
+	Path string `json:"path" yaml:"path"`
+}
+
+func (o *options) validateOutput() error {
+	switch o.output {
+	case outputTable, outputJSON, outputYAML:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q (use table, json or yaml)", o.output)
+	}
+}
+
+func (o *options) print(value any, table func()) error {
+	switch o.output {
+	case outputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case outputYAML:
+		encoder := yaml.NewEncoder(os.Stdout)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		table()
+		return nil
+	}
+}
+
+func toStatusOutput(statuses []neo4go.MigrationStatus) []statusOutput {
+	output := make([]statusOutput, 0, len(statuses))
+	for _, status := range statuses {
+		output = append(output, statusOutput{
+			Version:         status.Version,
+			Name:            status.Name,
+			Applied:         status.Applied,
+			AppliedAt:       status.AppliedAt,
+			Checksum:        status.Checksum,
+			Baselined:       status.Baselined,
+			Reversible:      status.Reversible,
+			Dirty:           status.Dirty,
+			Error:           status.Error,
+			FailedStatement: status.FailedStatement,
+		})
+	}
+	return output
+}
+
+func toMigrationOutput(results []neo4go.MigrationResult) []migrationOutput {
+	output := make([]migrationOutput, 0, len(results))
+	for _, result := range results {
+		migration := migrationOutput{
+			Version:    result.Version,
+			Name:       result.Name,
+			Direction:  string(result.Direction),
+			DurationMs: result.Duration.Milliseconds(),
+			Statements: result.Statements,
+			Counters:   countersOutput(result.Counters),
+		}
+		if result.Error != nil {
+			migration.Error = result.Error.Error()
+		}
+		output = append(output, migration)
+	}
+	return output
+}
+
+func toPlannedOutput(plan []neo4go.PlannedMigration) []plannedOutput {
+	output := make([]plannedOutput, 0, len(plan))
+	for _, migration := range plan {
+		statements := make([]statementOutput, 0, len(migration.Statements))
+		for _, stmt := range migration.Statements {
+			statements = append(statements, statementOutput{
+				Line: stmt.Line,
+				Kind: string(stmt.Kind),
+				Text: stmt.Text,
+			})
+		}
+
+		output = append(output, plannedOutput{
+			Version:     migration.Version,
+			Name:        migration.Name,
+			Direction:   string(migration.Direction),
+			Transaction: migration.TxMode.String(),
+			Go:          migration.Go,
+			Statements:  statements,
+		})
+	}
+	return output
+}
+
+func toIssueOutput(issues []neo4go.ValidationIssue) []issueOutput {
+	output := make([]issueOutput, 0, len(issues))
+	for _, issue := range issues {
+		output = append(output, issueOutput{
+			File:     issue.File,
+			Line:     issue.Line,
+			Severity: string(issue.Severity),
+			Rule:     issue.Rule,
+			Message:  issue.Message,
+		})
+	}
+	return output
+}
+
+func toSnapshotFile(snapshot neo4go.SchemaSnapshot) snapshotFile {
+	return snapshotFile{
+		Constraints: toSchemaObjectOutput(snapshot.Constraints),
+		Indexes:     toSchemaObjectOutput(snapshot.Indexes),
+	}
+}
+
+func toSchemaObjectOutput(objects []neo4go.SchemaObject) []schemaObjectOutput {
+	output := make([]schemaObjectOutput, 0, len(objects))
+	for _, object := range objects {
+		output = append(output, schemaObjectOutput(object))
+	}
+	return output
+}
+
+func (f snapshotFile) toSnapshot() neo4go.SchemaSnapshot {
+	var snapshot neo4go.SchemaSnapshot
+	for _, object := range f.Constraints {
+		snapshot.Constraints = append(snapshot.Constraints, neo4go.SchemaObject(object))
+	}
+	for _, object := range f.Indexes {
+		snapshot.Indexes = append(snapshot.Indexes, neo4go.SchemaObject(object))
+	}
+	return snapshot
+}
+
+func toSchemaDiffOutput(diff neo4go.SchemaDiff) schemaDiffOutput {
+	return schemaDiffOutput{
+		Missing:    toSchemaChangeOutput(diff.Missing),
+		Unexpected: toSchemaChangeOutput(diff.Unexpected),
+		Changed:    toSchemaChangeOutput(diff.Changed),
+	}
+}
+
+func toSchemaChangeOutput(changes []neo4go.SchemaChange) []schemaChangeOutput {
+	output := make([]schemaChangeOutput, 0, len(changes))
+	for _, change := range changes {
+		item := schemaChangeOutput{Kind: string(change.Kind), Name: change.Name}
+		if change.Expected != nil {
+			item.Expected = change.Expected.CreateStatement
+		}
+		if change.Actual != nil {
+			item.Actual = change.Actual.CreateStatement
+		}
+		output = append(output, item)
+	}
+	return output
+}
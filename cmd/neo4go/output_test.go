@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.kirha.ai/neo4go"
+	"gopkg.in/yaml.v3"
+)
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		done <- string(data)
+	}()
+
+	fn()
+
+	_ = writer.Close()
+	return <-done
+}
+
+func TestStructuredOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		decode func(data []byte, v any) error
+	}{
+		{name: "json", format: "json", decode: json.Unmarshal},
+		{name: "yaml", format: "yaml", decode: yaml.Unmarshal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NEO4J_URI", "bolt://localhost:7687")
+			t.Setenv("NEO4J_USERNAME", "neo4j")
+			t.Setenv("NEO4J_PASSWORD", "password")
+			dir := t.TempDir()
+
+			var runErr error
+			stdout := captureStdout(t, func() {
+				opts := &options{output: tt.format}
+				cfg, err := opts.config()
+				if err != nil {
+					runErr = err
+					return
+				}
+
+				cfg.Logger.Info("initialized schema migration tracking")
+				cfg.Logger.Warn("checksum mismatch", "version", 1)
+
+				root := newRootCmd()
+				root.SetArgs([]string{"create", "add_users", "--dir", dir, "-o", tt.format})
+				runErr = root.Execute()
+			})
+
+			if runErr != nil {
+				t.Fatalf("unexpected error: %v", runErr)
+			}
+
+			var output createOutput
+			if err := tt.decode([]byte(stdout), &output); err != nil {
+				t.Fatalf("stdout is not valid %s: %v\n%s", tt.format, err, stdout)
+			}
+
+			if filepath.Dir(output.Path) != dir || !strings.HasSuffix(output.Path, "_add_users.cypher") {
+				t.Errorf("unexpected path %q", output.Path)
+			}
+		})
+	}
+}
+
+func TestSnapshotFileRoundTrip(t *testing.T) {
+	snapshot := neo4go.SchemaSnapshot{
+		Constraints: []neo4go.SchemaObject{{
+			Name: "user_id_unique", Type: "UNIQUENESS", EntityType: "NODE",
+			LabelsOrTypes: []string{"User"}, Properties: []string{"id"},
+			CreateStatement: "CREATE CONSTRAINT `user_id_unique` FOR (n:`User`) REQUIRE (n.`id`) IS UNIQUE",
+		}},
+		Indexes: []neo4go.SchemaObject{{
+			Name: "user_email_idx", Type: "RANGE", EntityType: "NODE",
+			LabelsOrTypes: []string{"User"}, Properties: []string{"email"},
+			CreateStatement: "CREATE RANGE INDEX `user_email_idx` FOR (n:`User`) ON (n.`email`)",
+		}},
+	}
+
+	data, err := json.Marshal(toSnapshotFile(snapshot))
+	if err != nil {
+		t.Fatalf("failed to encode snapshot: %v", err)
+	}
+
+	var decoded snapshotFile
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+
+	if diff := neo4go.DiffSchema(decoded.toSnapshot(), snapshot); !diff.Empty() {
+		t.Errorf("expected round trip to preserve the snapshot, got %+v", diff)
+	}
+}
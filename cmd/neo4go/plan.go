@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"go.kirha.ai/neo4go"
+)
+
+func printPlan(opts *options, plan []neo4go.PlannedMigration) error {
+	return opts.print(toPlannedOutput(plan), func() {
+		if len(plan) == 0 {
+			fmt.Println("Nothing to do")
+			return
+		}
+
+		fmt.Printf("Dry run: %d migration(s) would be executed\n", len(plan))
+
+		for _, migration := range plan {
+			fmt.Printf("\n%s %d %s (%s)\n", strings.ToUpper(string(migration.Direction)), migration.Version, migration.Name, migration.TxMode)
+
+			if migration.Go {
+				fmt.Println("  Go migration")
+				continue
+			}
+
+			for i, stmt := range migration.Statements {
+				fmt.Printf("  [%d] line %d, %s:\n", i+1, stmt.Line, stmt.Kind)
+				for _, line := range strings.Split(stmt.Text, "\n") {
+					fmt.Printf("      %s\n", line)
+				}
+			}
+		}
+	})
+}
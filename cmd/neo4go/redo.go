@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.kirha.ai/neo4go"
+)
+
+func newRedoCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "redo",
+		Short: "Rollback the last migration and apply it again",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := opts.config()
+			if err != nil {
+				return err
+			}
+
+			migrator, err := neo4go.New(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create migrator: %w", err)
+			}
+			defer func() {
+				_ = migrator.Close()
+			}()
+
+			return runMigrations(cmd.Context(), opts, migrator, migrator.Redo,
+				"Migration redone successfully", "failed to redo migration")
+		},
+	}
+}
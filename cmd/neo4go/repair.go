@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.kirha.ai/neo4go"
+)
+
+func newRepairCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "repair",
+		Short: "Rewrite stored checksums to match the current migration files",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := opts.config()
+			if err != nil {
+				return err
+			}
+
+			migrator, err := neo4go.New(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create migrator: %w", err)
+			}
+			defer func() {
+				_ = migrator.Close()
+			}()
+
+			repaired, err := migrator.Repair(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to repair migrations: %w", err)
+			}
+
+			if repaired == nil {
+				repaired = []int{}
+			}
+
+			return opts.print(repairOutput{Repaired: repaired}, func() {
+				if len(repaired) == 0 {
+					fmt.Println("All checksums already match")
+					return
+				}
+
+				for _, version := range repaired {
+					fmt.Printf("Repaired checksum of version %d\n", version)
+				}
+			})
+		},
+	}
+}
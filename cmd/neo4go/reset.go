@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.kirha.ai/neo4go"
+)
+
+func newResetCmd(opts *options) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Rollback every applied migration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := opts.config()
+			if err != nil {
+				return err
+			}
+
+			if !yes {
+				prompt := fmt.Sprintf("Roll back every migration applied to database %q?", cfg.Database)
+				if !confirm(cmd.InOrStdin(), cmd.ErrOrStderr(), prompt) {
+					return fmt.Errorf("reset aborted")
+				}
+			}
+
+			migrator, err := neo4go.New(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create migrator: %w", err)
+			}
+			defer func() {
+				_ = migrator.Close()
+			}()
+
+			return runMigrations(cmd.Context(), opts, migrator, migrator.Reset,
+				"All migrations rolled back successfully", "failed to reset migrations")
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
+}
+
+func confirm(in io.Reader, out io.Writer, prompt string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", prompt)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(out)
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
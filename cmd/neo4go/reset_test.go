@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		expect bool
+	}{
+		{name: "yes", input: "yes\n", expect: true},
+		{name: "short yes", input: "Y\n", expect: true},
+		{name: "no", input: "n\n", expect: false},
+		{name: "empty answer", input: "\n", expect: false},
+		{name: "closed stdin", input: "", expect: false},
+		{name: "answer without newline", input: "y", expect: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got := confirm(strings.NewReader(tt.input), &out, "Roll back?")
+
+			if got != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+			if !strings.HasPrefix(out.String(), "Roll back? [y/N] ") {
+				t.Errorf("expected prompt on output, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestResetRequiresConfirmation(t *testing.T) {
+	t.Setenv("NEO4J_URI", "bolt://localhost:1")
+	t.Setenv("NEO4J_USERNAME", "neo4j")
+	t.Setenv("NEO4J_PASSWORD", "password")
+
+	var stderr bytes.Buffer
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"reset", "--dir", t.TempDir()})
+	cmd.SetIn(strings.NewReader("n\n"))
+	cmd.SetErr(&stderr)
+	cmd.SetOut(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "reset aborted") {
+		t.Fatalf("expected reset to be aborted, got %v", err)
+	}
+	if !strings.Contains(stderr.String(), `database "neo4j"`) {
+		t.Errorf("expected prompt naming the database, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.kirha.ai/neo4go"
+)
+
+func runMigrations(ctx context.Context, opts *options, migrator neo4go.Migrator, run func(ctx context.Context) ([]neo4go.MigrationResult, error), success, failure string) error {
+	start := time.Now()
+	results, runErr := run(ctx)
+	duration := time.Since(start)
+
+	if runErr != nil {
+		runErr = fmt.Errorf("%s: %w", failure, runErr)
+	}
+
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		if runErr != nil {
+			return runErr
+		}
+		return fmt.Errorf("failed to get version: %w", err)
+	}
+
+	output := runOutput{
+		Migrations: toMigrationOutput(results),
+		Version:    version,
+		DurationMs: duration.Milliseconds(),
+	}
+	if runErr != nil {
+		output.Error = runErr.Error()
+	}
+
+	if err := opts.print(output, func() {
+		for _, result := range results {
+			if result.Error != nil {
+				continue
+			}
+
+			verb := "Applied"
+			if result.Direction == neo4go.DirectionDown {
+				verb = "Rolled back"
+			}
+			fmt.Printf("%s %d %s (%d statements, %s)\n", verb, result.Version, result.Name, result.Statements, result.Duration.Round(time.Millisecond))
+		}
+
+		if runErr == nil {
+			fmt.Println(success)
+		}
+	}); err != nil {
+		return err
+	}
+
+	return runErr
+}
+
+func previousVersion(ctx context.Context, migrator neo4go.Migrator) (int, error) {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get status: %w", err)
+	}
+
+	var applied []int
+	for _, status := range statuses {
+		if status.Applied {
+			applied = append(applied, status.Version)
+		}
+	}
+
+	if len(applied) < 2 {
+		return 0, nil
+	}
+	return applied[len(applied)-2], nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.kirha.ai/neo4go"
+)
+
+func newSchemaCmd(opts *options) *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Snapshot the live schema and detect drift",
+	}
+
+	cmd.PersistentFlags().StringVar(&file, "file", "schema.json", "snapshot file; the Cypher dump is written next to it with a .cypher extension")
+
+	cmd.AddCommand(newSchemaDumpCmd(opts, &file))
+	cmd.AddCommand(newSchemaDiffCmd(opts, &file))
+
+	return cmd
+}
+
+func newSchemaDumpCmd(opts *options, file *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "dump",
+		Short: "Write the live constraints and indexes to the snapshot files",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			snapshot, err := liveSchema(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(toSnapshotFile(snapshot), "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode schema snapshot: %w", err)
+			}
+
+			cypherFile := strings.TrimSuffix(*file, filepath.Ext(*file)) + ".cypher"
+
+			if err := os.WriteFile(*file, append(data, '\n'), 0o644); err != nil {
+				return fmt.Errorf("failed to write schema snapshot: %w", err)
+			}
+
+			if err := os.WriteFile(cypherFile, []byte(snapshot.Cypher()), 0o644); err != nil {
+				return fmt.Errorf("failed to write schema snapshot: %w", err)
+			}
+
+			return opts.print(schemaDumpOutput{JSON: *file, Cypher: cypherFile}, func() {
+				fmt.Printf("Wrote %d constraints and %d indexes to %s and %s\n",
+					len(snapshot.Constraints), len(snapshot.Indexes), *file, cypherFile)
+			})
+		},
+	}
+}
+
+func newSchemaDiffCmd(opts *options, file *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the live schema with the committed snapshot",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			data, err := os.ReadFile(*file)
+			if err != nil {
+				return fmt.Errorf("failed to read schema snapshot: %w", err)
+			}
+
+			var committed snapshotFile
+			if err := json.Unmarshal(data, &committed); err != nil {
+				return fmt.Errorf("failed to parse schema snapshot %s: %w", *file, err)
+			}
+
+			snapshot, err := liveSchema(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			diff := neo4go.DiffSchema(committed.toSnapshot(), snapshot)
+
+			if err := opts.print(toSchemaDiffOutput(diff), func() {
+				printSchemaDiff(diff, *file)
+			}); err != nil {
+				return err
+			}
+
+			if !diff.Empty() {
+				return fmt.Errorf("live schema differs from %s", *file)
+			}
+			return nil
+		},
+	}
+}
+
+func liveSchema(cmd *cobra.Command, opts *options) (neo4go.SchemaSnapshot, error) {
+	cfg, err := opts.config()
+	if err != nil {
+		return neo4go.SchemaSnapshot{}, err
+	}
+
+	migrator, err := neo4go.New(cfg)
+	if err != nil {
+		return neo4go.SchemaSnapshot{}, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	defer func() {
+		_ = migrator.Close()
+	}()
+
+	snapshot, err := migrator.SchemaSnapshot(cmd.Context())
+	if err != nil {
+		return neo4go.SchemaSnapshot{}, fmt.Errorf("failed to read schema: %w", err)
+	}
+	return snapshot, nil
+}
+
+func printSchemaDiff(diff neo4go.SchemaDiff, file string) {
+	if diff.Empty() {
+		fmt.Printf("Live schema matches %s\n", file)
+		return
+	}
+
+	for _, change := range diff.Missing {
+		fmt.Printf("- %s %s (in %s, missing from the database)\n", change.Kind, change.Name, file)
+	}
+	for _, change := range diff.Unexpected {
+		fmt.Printf("+ %s %s (in the database, not in %s)\n", change.Kind, change.Name, file)
+		if change.Actual.CreateStatement != "" {
+			fmt.Printf("    %s\n", change.Actual.CreateStatement)
+		}
+	}
+	for _, change := range diff.Changed {
+		fmt.Printf("~ %s %s\n", change.Kind, change.Name)
+		fmt.Printf("    expected: %s\n", change.Expected.CreateStatement)
+		fmt.Printf("    actual:   %s\n", change.Actual.CreateStatement)
+	}
+}
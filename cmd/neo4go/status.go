@@ -2,18 +2,20 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"go.kirha.ai/neo4go"
 )
 
-func newStatusCmd() *cobra.Command {
+func newStatusCmd(opts *options) *cobra.Command {
 	return &cobra.Command{
 		Use:   "status",
 		Short: "Show migration status",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			cfg, err := getConfigFromEnv()
+			cfg, err := opts.config()
 			if err != nil {
 				return err
 			}
@@ -31,30 +33,54 @@ func newStatusCmd() *cobra.Command {
 				return fmt.Errorf("failed to get status: %w", err)
 			}
 
-			fmt.Println("Migration Status:")
-			fmt.Println("Version | Name                  | Applied | Applied At")
-			fmt.Println("--------|------------------------|---------|-------------------------")
-
-			for _, status := range statuses {
-				applied := "No"
-				appliedAt := "-"
-
-				if status.Applied {
-					applied = "Yes"
-					if status.AppliedAt != nil {
-						appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
-					}
-				}
-
-				fmt.Printf("%-7d | %-22s | %-7s | %s\n",
-					status.Version,
-					status.Name,
-					applied,
-					appliedAt,
-				)
+			return opts.print(toStatusOutput(statuses), func() {
+				printStatusTable(statuses)
+			})
+		},
+	}
+}
+
+func printStatusTable(statuses []neo4go.MigrationStatus) {
+	fmt.Println("Migration Status:")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
+	fmt.Fprintln(w, "Version\t Name\t Applied\t Applied At\t Reversible")
+
+	for _, status := range statuses {
+		applied := "No"
+		appliedAt := "-"
+
+		if status.Applied {
+			applied = "Yes"
+			if status.Baselined {
+				applied = "Baselined"
 			}
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+		}
 
-			return nil
-		},
+		if status.Dirty {
+			applied = "Dirty"
+		}
+
+		reversible := "Yes"
+		if !status.Reversible {
+			reversible = "No"
+		}
+
+		fmt.Fprintf(w, "%d\t %s\t %s\t %s\t %s\n", status.Version, status.Name, applied, appliedAt, reversible)
+	}
+
+	_ = w.Flush()
+
+	for _, status := range statuses {
+		if status.Dirty {
+			fmt.Printf("\nVersion %d is dirty: failed at statement %d: %s\n",
+				status.Version,
+				status.FailedStatement,
+				status.Error,
+			)
+		}
 	}
 }
@@ -2,18 +2,21 @@ package main
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/spf13/cobra"
 	"go.kirha.ai/neo4go"
 )
 
-func newUpCmd() *cobra.Command {
-	return &cobra.Command{
+func newUpCmd(opts *options) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
 		Use:   "up",
 		Short: "Run all pending migrations",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			cfg, err := getConfigFromEnv()
+			cfg, err := opts.config()
 			if err != nil {
 				return err
 			}
@@ -26,12 +29,21 @@ func newUpCmd() *cobra.Command {
 				_ = migrator.Close()
 			}()
 
-			if err := migrator.Up(cmd.Context()); err != nil {
-				return fmt.Errorf("failed to run migrations: %w", err)
+			if dryRun {
+				plan, err := migrator.Plan(cmd.Context(), neo4go.DirectionUp, math.MaxInt)
+				if err != nil {
+					return fmt.Errorf("failed to plan migrations: %w", err)
+				}
+
+				return printPlan(opts, plan)
 			}
 
-			fmt.Println("All migrations applied successfully")
-			return nil
+			return runMigrations(cmd.Context(), opts, migrator, migrator.UpWithResults,
+				"All migrations applied successfully", "failed to run migrations")
 		},
 	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the migrations and statements that would run without executing them")
+
+	return cmd
 }
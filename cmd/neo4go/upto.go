@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -8,8 +9,10 @@ import (
 	"go.kirha.ai/neo4go"
 )
 
-func newUpToCmd() *cobra.Command {
-	return &cobra.Command{
+func newUpToCmd(opts *options) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
 		Use:   "up-to <version>",
 		Short: "Migrate up to a specific version",
 		Args:  cobra.ExactArgs(1),
@@ -19,7 +22,7 @@ func newUpToCmd() *cobra.Command {
 				return fmt.Errorf("invalid version number: %w", err)
 			}
 
-			cfg, err := getConfigFromEnv()
+			cfg, err := opts.config()
 			if err != nil {
 				return err
 			}
@@ -32,12 +35,24 @@ func newUpToCmd() *cobra.Command {
 				_ = migrator.Close()
 			}()
 
-			if err := migrator.UpTo(cmd.Context(), version); err != nil {
-				return fmt.Errorf("failed to migrate to version %d: %w", version, err)
+			if dryRun {
+				plan, err := migrator.Plan(cmd.Context(), neo4go.DirectionUp, version)
+				if err != nil {
+					return fmt.Errorf("failed to plan migrations: %w", err)
+				}
+
+				return printPlan(opts, plan)
 			}
 
-			fmt.Printf("Migrated to version %d successfully\n", version)
-			return nil
+			upTo := func(ctx context.Context) ([]neo4go.MigrationResult, error) {
+				return migrator.UpToWithResults(ctx, version)
+			}
+			return runMigrations(cmd.Context(), opts, migrator, upTo,
+				fmt.Sprintf("Migrated to version %d successfully", version), fmt.Sprintf("failed to migrate to version %d", version))
 		},
 	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the migrations and statements that would run without executing them")
+
+	return cmd
 }
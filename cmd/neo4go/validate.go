@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.kirha.ai/neo4go"
+)
+
+func newValidateCmd(opts *options) *cobra.Command {
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check migration files for mistakes without connecting to the database",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			resolved, err := opts.settings()
+			if err != nil {
+				return err
+			}
+
+			issues, err := neo4go.Validate(os.DirFS(resolved.MigrationsDir))
+			if err != nil {
+				return fmt.Errorf("failed to validate migrations: %w", err)
+			}
+
+			errorCount, warningCount := 0, 0
+			for _, issue := range issues {
+				if issue.Severity == neo4go.SeverityError {
+					errorCount++
+				} else {
+					warningCount++
+				}
+			}
+
+			if err := opts.print(toIssueOutput(issues), func() {
+				for _, issue := range issues {
+					location := issue.File
+					if issue.Line > 0 {
+						location = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+					}
+					fmt.Printf("%s: %s: %s [%s]\n", location, issue.Severity, issue.Message, issue.Rule)
+				}
+
+				if len(issues) == 0 {
+					fmt.Println("All migrations are valid")
+				}
+			}); err != nil {
+				return err
+			}
+
+			if errorCount > 0 || (strict && warningCount > 0) {
+				return fmt.Errorf("validation found %d errors and %d warnings", errorCount, warningCount)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&strict, "strict", false, "fail on warnings as well as errors")
+
+	return cmd
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateCmd(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		args        []string
+		expectRules []string
+		expectError bool
+	}{
+		{
+			name: "valid migrations",
+			files: map[string]string{
+				"001_init.cypher": "-- +neo4go Up\nCREATE INDEX i1 IF NOT EXISTS FOR (n:A) ON (n.x);\n-- +neo4go Down\nDROP INDEX i1 IF EXISTS;\n",
+			},
+			expectRules: []string{},
+		},
+		{
+			name: "warnings pass by default",
+			files: map[string]string{
+				"001_init.cypher": "-- +neo4go Up\nCREATE INDEX i1 FOR (n:A) ON (n.x);\n-- +neo4go Down\nDROP INDEX i1 IF EXISTS;\n",
+			},
+			expectRules: []string{"missing-if-exists"},
+		},
+		{
+			name: "warnings fail with strict",
+			files: map[string]string{
+				"001_init.cypher": "-- +neo4go Up\nCREATE INDEX i1 FOR (n:A) ON (n.x);\n-- +neo4go Down\nDROP INDEX i1 IF EXISTS;\n",
+			},
+			args:        []string{"--strict"},
+			expectRules: []string{"missing-if-exists"},
+			expectError: true,
+		},
+		{
+			name: "errors fail",
+			files: map[string]string{
+				"001_init.cypher": "-- +neo4go Up\nMATCH (n) RETURN n;\n-- +neo4go Down\nMATCH (n) RETURN n;\n",
+				"init.cypher":     "-- +neo4go Up\nMATCH (n) RETURN n;\n",
+			},
+			expectRules: []string{"file-name"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+
+			var runErr error
+			stdout := captureStdout(t, func() {
+				root := newRootCmd()
+				root.SetArgs(append([]string{"validate", "--dir", dir, "-o", "json"}, tt.args...))
+				root.SilenceUsage = true
+				root.SilenceErrors = true
+				runErr = root.Execute()
+			})
+
+			if (runErr != nil) != tt.expectError {
+				t.Fatalf("expected error=%v, got %v", tt.expectError, runErr)
+			}
+
+			var issues []issueOutput
+			if err := json.Unmarshal([]byte(stdout), &issues); err != nil {
+				t.Fatalf("stdout is not valid json: %v\n%s", err, stdout)
+			}
+
+			rules := []string{}
+			for _, issue := range issues {
+				rules = append(rules, issue.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.expectRules) {
+				t.Errorf("expected rules %v, got %v", tt.expectRules, rules)
+			}
+		})
+	}
+}
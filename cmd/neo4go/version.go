@@ -7,13 +7,13 @@ import (
 	"go.kirha.ai/neo4go"
 )
 
-func newVersionCmd() *cobra.Command {
+func newVersionCmd(opts *options) *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
 		Short: "Show current migration version",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			cfg, err := getConfigFromEnv()
+			cfg, err := opts.config()
 			if err != nil {
 				return err
 			}
@@ -31,13 +31,13 @@ func newVersionCmd() *cobra.Command {
 				return fmt.Errorf("failed to get version: %w", err)
 			}
 
-			if version == 0 {
-				fmt.Println("No migrations applied yet")
-			} else {
-				fmt.Printf("Current version: %d\n", version)
-			}
-
-			return nil
+			return opts.print(versionOutput{Version: version}, func() {
+				if version == 0 {
+					fmt.Println("No migrations applied yet")
+				} else {
+					fmt.Printf("Current version: %d\n", version)
+				}
+			})
 		},
 	}
 }
@@ -7,20 +7,151 @@ type Migration struct {
 	Name     string
 	UpSQL    string
 	DownSQL  string
+	UpLine   int
+	DownLine int
+	TxMode   TransactionMode
 	Checksum string
+
+	DownDerived  bool
+	Irreversible bool
+
+	goFuncs *goMigration
+}
+
+type PlannedMigration struct {
+	Version    int
+	Name       string
+	Direction  Direction
+	TxMode     TransactionMode
+	Statements []Statement
+	Go         bool
+}
+
+type MigrationResult struct {
+	Version    int
+	Name       string
+	Direction  Direction
+	Duration   time.Duration
+	Statements int
+	Counters   Counters
+	Error      error
+}
+
+type Counters struct {
+	NodesCreated         int
+	NodesDeleted         int
+	RelationshipsCreated int
+	RelationshipsDeleted int
+	PropertiesSet        int
+	LabelsAdded          int
+	LabelsRemoved        int
+	IndexesAdded         int
+	IndexesRemoved       int
+	ConstraintsAdded     int
+	ConstraintsRemoved   int
+}
+
+type Direction string
+
+const (
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+type TransactionMode int
+
+const (
+	TransactionDefault TransactionMode = iota
+	TransactionPerStatement
+	NoTransaction
+)
+
+func (t TransactionMode) String() string {
+	switch t {
+	case TransactionPerStatement:
+		return "TransactionPerStatement"
+	case NoTransaction:
+		return "NoTransaction"
+	default:
+		return "Default"
+	}
+}
+
+type StatementKind string
+
+const (
+	StatementData   StatementKind = "data"
+	StatementSchema StatementKind = "schema"
+)
+
+type Statement struct {
+	Text string
+	Line int
+	Kind StatementKind
 }
 
 type MigrationStatus struct {
-	Version   int
-	Name      string
-	Applied   bool
-	AppliedAt *time.Time
-	Checksum  string
+	Version         int
+	Name            string
+	Applied         bool
+	AppliedAt       *time.Time
+	Checksum        string
+	Baselined       bool
+	Reversible      bool
+	Dirty           bool
+	Error           string
+	FailedStatement int
 }
 
 type MigrationRecord struct {
-	Version   int
-	Name      string
-	AppliedAt time.Time
-	Checksum  string
+	Version         int
+	Name            string
+	AppliedAt       time.Time
+	Checksum        string
+	Baselined       bool
+	Dirty           bool
+	Error           string
+	FailedStatement int
+}
+
+type ChecksumPolicy int
+
+const (
+	ChecksumStrict ChecksumPolicy = iota
+	ChecksumWarn
+	ChecksumIgnore
+)
+
+type SchemaObjectKind string
+
+const (
+	SchemaObjectConstraint SchemaObjectKind = "constraint"
+	SchemaObjectIndex      SchemaObjectKind = "index"
+)
+
+type SchemaSnapshot struct {
+	Constraints []SchemaObject
+	Indexes     []SchemaObject
+}
+
+type SchemaObject struct {
+	Name            string
+	Type            string
+	EntityType      string
+	LabelsOrTypes   []string
+	Properties      []string
+	CreateStatement string
+}
+
+type SchemaChange struct {
+	Kind     SchemaObjectKind
+	Name     string
+	Expected *SchemaObject
+	Actual   *SchemaObject
+}
+
+type SchemaDiff struct {
+	Missing    []SchemaChange
+	Unexpected []SchemaChange
+	Changed    []SchemaChange
 }
@@ -1,14 +1,64 @@
 package neo4go
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrNoMigrations       = errors.New("no migrations found")
-	ErrInvalidVersion     = errors.New("invalid version number")
-	ErrMigrationNotFound  = errors.New("migration not found")
-	ErrNoUpStatement      = errors.New("migration missing up statement")
-	ErrNoDownStatement    = errors.New("migration missing down statement")
-	ErrInvalidConfig      = errors.New("invalid configuration")
-	ErrDatabaseConnection = errors.New("database connection error")
-	ErrTransactionFailed  = errors.New("transaction failed")
+	ErrNoMigrations          = errors.New("no migrations found")
+	ErrInvalidVersion        = errors.New("invalid version number")
+	ErrMigrationNotFound     = errors.New("migration not found")
+	ErrNoUpStatement         = errors.New("migration missing up statement")
+	ErrNoDownStatement       = errors.New("migration missing down statement")
+	ErrInvalidMigrationFile  = errors.New("invalid migration file")
+	ErrInvalidConfig         = errors.New("invalid configuration")
+	ErrDatabaseConnection    = errors.New("database connection error")
+	ErrTransactionFailed     = errors.New("transaction failed")
+	ErrLockTimeout           = errors.New("timed out acquiring migration lock")
+	ErrLockLost              = errors.New("migration lock lost")
+	ErrDirtyMigration        = errors.New("migration is in a dirty state")
+	ErrChecksumMismatch      = errors.New("migration checksum mismatch")
+	ErrDuplicateVersion      = errors.New("duplicate migration version")
+	ErrHookAborted           = errors.New("aborted by hook")
+	ErrIrreversibleMigration = errors.New("migration is irreversible")
 )
+
+type ChecksumMismatchError struct {
+	Versions []int
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%v: versions %v", ErrChecksumMismatch, e.Versions)
+}
+
+func (e *ChecksumMismatchError) Unwrap() error {
+	return ErrChecksumMismatch
+}
+
+type IrreversibleMigrationError struct {
+	Version int
+	Name    string
+}
+
+func (e *IrreversibleMigrationError) Error() string {
+	return fmt.Sprintf("%v: version %d (%s) cannot be rolled back", ErrIrreversibleMigration, e.Version, e.Name)
+}
+
+func (e *IrreversibleMigrationError) Unwrap() error {
+	return ErrIrreversibleMigration
+}
+
+type DuplicateVersionError struct {
+	Version int
+	First   string
+	Second  string
+}
+
+func (e *DuplicateVersionError) Error() string {
+	return fmt.Sprintf("%v: version %d is used by both %s and %s", ErrDuplicateVersion, e.Version, e.First, e.Second)
+}
+
+func (e *DuplicateVersionError) Unwrap() error {
+	return ErrDuplicateVersion
+}
@@ -0,0 +1,84 @@
+package neo4go
+
+import (
+	"context"
+	"fmt"
+)
+
+type Hooks struct {
+	BeforeAll  func(ctx context.Context, direction Direction, migrations []Migration) error
+	BeforeEach func(ctx context.Context, migration Migration, direction Direction) error
+	AfterEach  func(ctx context.Context, migration Migration, result MigrationResult) error
+	AfterAll   func(ctx context.Context, results []MigrationResult, err error)
+	OnError    func(ctx context.Context, migration Migration, err error)
+}
+
+func (m *migrator) run(ctx context.Context, direction Direction, plan func(ctx context.Context) ([]Migration, error)) ([]MigrationResult, error) {
+	return m.runLocked(ctx, func(ctx context.Context) ([]MigrationResult, error) {
+		migrations, err := plan(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return m.runEach(ctx, migrations, direction)
+	})
+}
+
+func (m *migrator) runLocked(ctx context.Context, fn func(ctx context.Context) ([]MigrationResult, error)) ([]MigrationResult, error) {
+	var results []MigrationResult
+	err := m.withLock(ctx, func(ctx context.Context) error {
+		var err error
+		results, err = fn(ctx)
+		return err
+	})
+
+	m.updateSchemaState(ctx)
+
+	if m.hooks.AfterAll != nil {
+		m.hooks.AfterAll(ctx, results, err)
+	}
+
+	return results, err
+}
+
+func (m *migrator) runEach(ctx context.Context, migrations []Migration, direction Direction) ([]MigrationResult, error) {
+	results := make([]MigrationResult, 0, len(migrations))
+
+	if m.hooks.BeforeAll != nil {
+		if err := m.hooks.BeforeAll(ctx, direction, migrations); err != nil {
+			return results, fmt.Errorf("%w: BeforeAll: %w", ErrHookAborted, err)
+		}
+	}
+
+	for _, migration := range migrations {
+		if m.hooks.BeforeEach != nil {
+			if err := m.hooks.BeforeEach(ctx, migration, direction); err != nil {
+				err = fmt.Errorf("%w: BeforeEach for migration %d: %w", ErrHookAborted, migration.Version, err)
+				m.onError(ctx, migration, err)
+				return results, err
+			}
+		}
+
+		result, err := m.migrate(ctx, migration, direction)
+		results = append(results, result)
+		if err != nil {
+			m.onError(ctx, migration, err)
+			return results, err
+		}
+
+		if m.hooks.AfterEach != nil {
+			if err := m.hooks.AfterEach(ctx, migration, result); err != nil {
+				err = fmt.Errorf("%w: AfterEach for migration %d: %w", ErrHookAborted, migration.Version, err)
+				m.onError(ctx, migration, err)
+				return results, err
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (m *migrator) onError(ctx context.Context, migration Migration, err error) {
+	if m.hooks.OnError != nil {
+		m.hooks.OnError(ctx, migration, err)
+	}
+}
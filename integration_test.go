@@ -5,12 +5,16 @@ package neo4go
 
 import (
 	"context"
+	"errors"
 	"os"
+	"reflect"
 	"testing"
 	"testing/fstest"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func getTestConfig() Config {
@@ -375,6 +379,10 @@ DROP CONSTRAINT test_c_modified IF EXISTS;`),
 	if originalChecksum == newChecksum {
 		t.Error("checksums should differ when migration content changes")
 	}
+
+	if err := migrator2.Up(ctx); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("expected Up() to fail with %v, got %v", ErrChecksumMismatch, err)
+	}
 }
 
 func TestIntegrationIdempotency(t *testing.T) {
@@ -466,6 +474,61 @@ DROP CONSTRAINT invalid_c IF EXISTS;`),
 	}
 }
 
+func TestIntegrationMixedSchemaAndData(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.MigrationsFS = fstest.MapFS{
+		"001_mixed.cypher": &fstest.MapFile{
+			Data: []byte(`-- +neo4go Up
+CREATE CONSTRAINT mixed_c IF NOT EXISTS FOR (n:Mixed) REQUIRE n.id IS UNIQUE;
+CREATE (:Mixed {id: 1});
+CREATE INDEX mixed_name_idx IF NOT EXISTS FOR (n:Mixed) ON (n.name);
+
+-- +neo4go Down
+DROP INDEX mixed_name_idx IF EXISTS;
+MATCH (n:Mixed) DELETE n;
+DROP CONSTRAINT mixed_c IF EXISTS;`),
+		},
+	}
+	cfg.MigrationsDir = ""
+
+	cleanupDatabase(t, cfg)
+	defer cleanupDatabase(t, cfg)
+
+	migrator, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create migrator: %v", err)
+	}
+	defer migrator.Close()
+
+	ctx := context.Background()
+
+	results, err := migrator.UpWithResults(ctx)
+	if err != nil {
+		t.Fatalf("failed to apply mixed migration: %v", err)
+	}
+	verifyVersion(t, ctx, migrator, 1)
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	up := results[0].Counters
+	if results[0].Statements != 3 || up.ConstraintsAdded != 1 || up.IndexesAdded != 1 || up.NodesCreated != 1 {
+		t.Errorf("unexpected up result: %d statements, counters %+v", results[0].Statements, up)
+	}
+
+	results, err = migrator.DownWithResults(ctx)
+	if err != nil {
+		t.Fatalf("failed to rollback mixed migration: %v", err)
+	}
+	verifyVersion(t, ctx, migrator, 0)
+
+	down := results[0].Counters
+	if results[0].Direction != DirectionDown || down.ConstraintsRemoved != 1 || down.IndexesRemoved != 1 || down.NodesDeleted != 1 {
+		t.Errorf("unexpected down result: %s, counters %+v", results[0].Direction, down)
+	}
+}
+
 func TestIntegrationConcurrentMigrations(t *testing.T) {
 	cfg := getTestConfig()
 	cfg.MigrationsFS = fstest.MapFS{
@@ -500,18 +563,12 @@ DROP CONSTRAINT conc_c IF EXISTS;`),
 		}()
 	}
 
-	successCount := 0
 	for i := 0; i < 3; i++ {
-		err := <-results
-		if err == nil {
-			successCount++
+		if err := <-results; err != nil {
+			t.Fatalf("concurrent Up() failed: %v", err)
 		}
 	}
 
-	if successCount == 0 {
-		t.Fatal("at least one migration should succeed")
-	}
-
 	migrator, err := New(cfg)
 	if err != nil {
 		t.Fatalf("failed to create verification migrator: %v", err)
@@ -527,3 +584,333 @@ DROP CONSTRAINT conc_c IF EXISTS;`),
 		t.Errorf("expected final version 1, got %d", version)
 	}
 }
+
+func countRows(t *testing.T, cfg Config, query string) int64 {
+	t.Helper()
+
+	driver, err := neo4j.NewDriverWithContext(
+		cfg.URI,
+		neo4j.BasicAuth(cfg.Username, cfg.Password, ""),
+	)
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	defer driver.Close(context.Background())
+
+	ctx := context.Background()
+	result, err := neo4j.ExecuteQuery(ctx, driver, query, nil, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase(cfg.Database))
+	if err != nil {
+		t.Fatalf("failed to run %q: %v", query, err)
+	}
+
+	count, _ := result.Records[0].Values[0].(int64)
+	return count
+}
+
+func TestIntegrationCallInTransactions(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.MigrationsFS = fstest.MapFS{
+		"001_seed.cypher": &fstest.MapFile{
+			Data: []byte(`-- +neo4go Up
+UNWIND range(1, 50) AS i CREATE (:Batch {id: i});
+
+-- +neo4go Down
+MATCH (b:Batch) DELETE b;`),
+		},
+		"002_backfill.cypher": &fstest.MapFile{
+			Data: []byte(`-- +neo4go NoTransaction
+-- +neo4go Up
+MATCH (b:Batch)
+CALL { WITH b SET b.done = true } IN TRANSACTIONS OF 10 ROWS;
+
+-- +neo4go Down
+MATCH (b:Batch)
+CALL { WITH b REMOVE b.done } IN TRANSACTIONS OF 10 ROWS;`),
+		},
+	}
+	cfg.MigrationsDir = ""
+
+	cleanupDatabase(t, cfg)
+	defer cleanupDatabase(t, cfg)
+
+	migrator, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create migrator: %v", err)
+	}
+	defer migrator.Close()
+
+	ctx := context.Background()
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("failed to apply CALL IN TRANSACTIONS migration: %v", err)
+	}
+	verifyVersion(t, ctx, migrator, 2)
+
+	if done := countRows(t, cfg, "MATCH (b:Batch) WHERE b.done RETURN count(b)"); done != 50 {
+		t.Errorf("expected 50 backfilled nodes, got %d", done)
+	}
+
+	if err := migrator.Down(ctx); err != nil {
+		t.Fatalf("failed to roll back CALL IN TRANSACTIONS migration: %v", err)
+	}
+	verifyVersion(t, ctx, migrator, 1)
+
+	if done := countRows(t, cfg, "MATCH (b:Batch) WHERE b.done IS NOT NULL RETURN count(b)"); done != 0 {
+		t.Errorf("expected backfill to be removed, got %d nodes", done)
+	}
+}
+
+type failingRecordStorage struct {
+	*neo4jStorage
+}
+
+func (s *failingRecordStorage) RecordMigrationTx(ctx context.Context, tx neo4j.ManagedTransaction, migration Migration) error {
+	if err := s.neo4jStorage.RecordMigrationTx(ctx, tx, migration); err != nil {
+		return err
+	}
+	return errors.New("record rejected")
+}
+
+func TestIntegrationAtomicRecording(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		failRecordTx bool
+	}{
+		{
+			name: "record failure rolls back the data",
+			content: `-- +neo4go Up
+CREATE (:Atomic {id: 1});
+CREATE (:Atomic {id: 2});
+
+-- +neo4go Down
+MATCH (n:Atomic) DELETE n;`,
+			failRecordTx: true,
+		},
+		{
+			name: "failed last batch leaves no record",
+			content: `-- +neo4go Up
+CREATE (:Atomic {id: 1});
+THIS IS INVALID CYPHER THAT WILL FAIL;
+
+-- +neo4go Down
+MATCH (n:Atomic) DELETE n;`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := getTestConfig()
+			filesystem := fstest.MapFS{
+				"001_atomic.cypher": &fstest.MapFile{Data: []byte(tt.content)},
+			}
+
+			cleanupDatabase(t, cfg)
+			defer cleanupDatabase(t, cfg)
+
+			driver, err := neo4j.NewDriverWithContext(cfg.URI, neo4j.BasicAuth(cfg.Username, cfg.Password, ""))
+			if err != nil {
+				t.Fatalf("failed to create driver: %v", err)
+			}
+			defer driver.Close(context.Background())
+
+			logger := NewNopLogger()
+			var storage Storage = newNeo4jStorage(driver, cfg.Database, logger)
+			if tt.failRecordTx {
+				storage = &failingRecordStorage{neo4jStorage: newNeo4jStorage(driver, cfg.Database, logger)}
+			}
+
+			m, err := newMigrator(driver, storage, filesystem, ".", cfg.Database, logger)
+			if err != nil {
+				t.Fatalf("failed to create migrator: %v", err)
+			}
+
+			ctx := context.Background()
+
+			if err := m.Up(ctx); err == nil {
+				t.Fatal("expected Up to fail")
+			}
+
+			if nodes := countRows(t, cfg, "MATCH (n:Atomic) RETURN count(n)"); nodes != 0 {
+				t.Errorf("expected data to be rolled back, found %d nodes", nodes)
+			}
+
+			if records := countRows(t, cfg, "MATCH (m:SchemaMigration) RETURN count(m)"); records != 0 {
+				t.Errorf("expected no migration record, found %d", records)
+			}
+		})
+	}
+}
+
+func TestIntegrationDirtyState(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.MigrationsFS = fstest.MapFS{
+		"001_partial.cypher": &fstest.MapFile{
+			Data: []byte(`-- +neo4go NoTransaction
+-- +neo4go Up
+CREATE (:Partial {id: 1});
+THIS IS INVALID CYPHER THAT WILL FAIL;
+
+-- +neo4go Down
+MATCH (n:Partial) DELETE n;`),
+		},
+	}
+	cfg.MigrationsDir = ""
+
+	cleanupDatabase(t, cfg)
+	defer cleanupDatabase(t, cfg)
+
+	migrator, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create migrator: %v", err)
+	}
+	defer migrator.Close()
+
+	ctx := context.Background()
+
+	if err := migrator.Up(ctx); err == nil {
+		t.Fatal("expected partially applied migration to fail")
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+
+	status := statuses[0]
+	if !status.Dirty || status.Applied {
+		t.Errorf("expected version 1 to be dirty and not applied, got dirty=%v applied=%v", status.Dirty, status.Applied)
+	}
+
+	if status.FailedStatement != 2 {
+		t.Errorf("expected failed statement 2, got %d", status.FailedStatement)
+	}
+
+	if status.Error == "" {
+		t.Error("expected the failure to be recorded")
+	}
+
+	if err := migrator.Up(ctx); !errors.Is(err, ErrDirtyMigration) {
+		t.Errorf("expected ErrDirtyMigration, got %v", err)
+	}
+}
+
+func TestIntegrationStatementSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+
+	cfg := getTestConfig()
+	cfg.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	cfg.MigrationsFS = fstest.MapFS{
+		"001_traced.cypher": &fstest.MapFile{
+			Data: []byte(`-- +neo4go Up
+CREATE CONSTRAINT traced_c IF NOT EXISTS FOR (n:Traced) REQUIRE n.id IS UNIQUE;
+CREATE (:Traced {id: 1});
+
+-- +neo4go Down
+MATCH (n:Traced) DELETE n;
+DROP CONSTRAINT traced_c IF EXISTS;`),
+		},
+	}
+	cfg.MigrationsDir = ""
+
+	cleanupDatabase(t, cfg)
+	defer cleanupDatabase(t, cfg)
+
+	migrator, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create migrator: %v", err)
+	}
+	defer migrator.Close()
+
+	ctx := context.Background()
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("failed to apply migration: %v", err)
+	}
+	defer migrator.Down(ctx)
+
+	var migrationSpan tracetest.SpanStub
+	var statementSpans []tracetest.SpanStub
+	for _, span := range exporter.GetSpans() {
+		switch span.Name {
+		case "neo4go.migration":
+			migrationSpan = span
+		case "neo4go.statement":
+			statementSpans = append(statementSpans, span)
+		}
+	}
+
+	if len(statementSpans) != 2 {
+		t.Fatalf("expected 2 statement spans, got %d", len(statementSpans))
+	}
+
+	for _, span := range statementSpans {
+		if span.Parent.SpanID() != migrationSpan.SpanContext.SpanID() {
+			t.Errorf("statement span is not a child of the migration span")
+		}
+
+		if _, ok := spanAttr(span, attrDBQueryText); !ok {
+			t.Errorf("expected statement text on span")
+		}
+	}
+}
+
+func TestIntegrationSchemaSnapshot(t *testing.T) {
+	cfg := getTestConfig()
+	cfg.MigrationsFS = fstest.MapFS{
+		"001_create_users.cypher": &fstest.MapFile{
+			Data: []byte(`-- +neo4go Up
+CREATE CONSTRAINT snapshot_user_id IF NOT EXISTS FOR (u:SnapshotUser) REQUIRE u.id IS UNIQUE;
+CREATE INDEX snapshot_user_email IF NOT EXISTS FOR (u:SnapshotUser) ON (u.email);
+
+-- +neo4go Down
+DROP INDEX snapshot_user_email IF EXISTS;
+DROP CONSTRAINT snapshot_user_id IF EXISTS;`),
+		},
+	}
+	cfg.MigrationsDir = ""
+
+	cleanupDatabase(t, cfg)
+	defer cleanupDatabase(t, cfg)
+
+	migrator, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create migrator: %v", err)
+	}
+	defer migrator.Close()
+
+	ctx := context.Background()
+	defer migrator.Reset(ctx)
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	snapshot, err := migrator.SchemaSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("failed to take schema snapshot: %v", err)
+	}
+
+	names := make(map[string]SchemaObject)
+	for _, object := range append(snapshot.Constraints, snapshot.Indexes...) {
+		names[object.Name] = object
+	}
+
+	if _, exists := names["schema_migration_version"]; exists {
+		t.Error("expected internal constraints to be left out of the snapshot")
+	}
+
+	constraint, exists := names["snapshot_user_id"]
+	if !exists || constraint.CreateStatement == "" {
+		t.Errorf("expected snapshot_user_id with a create statement, got %+v", constraint)
+	}
+
+	index, exists := names["snapshot_user_email"]
+	if !exists || !reflect.DeepEqual(index.Properties, []string{"email"}) {
+		t.Errorf("expected snapshot_user_email on email, got %+v", index)
+	}
+
+	if diff := DiffSchema(snapshot, snapshot); !diff.Empty() {
+		t.Errorf("expected snapshot to match itself, got %+v", diff)
+	}
+}
@@ -0,0 +1,150 @@
+package neo4go
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var errUnterminatedQuote = errors.New("unterminated")
+
+var schemaStatementPattern = regexp.MustCompile(`(?i)^(CREATE|DROP)\s+(OR\s+REPLACE\s+)?((RANGE|TEXT|POINT|LOOKUP|FULLTEXT|VECTOR|BTREE)\s+)?(INDEX|CONSTRAINT)\b`)
+
+func splitStatements(cypher string, firstLine int) ([]Statement, error) {
+	var statements []Statement
+	var current strings.Builder
+
+	line := firstLine
+	statementLine := 0
+	atLineStart := true
+
+	flush := func() {
+		text := strings.TrimSpace(current.String())
+		if text != "" {
+			statements = append(statements, Statement{
+				Text: text,
+				Line: statementLine,
+				Kind: classifyStatement(text),
+			})
+		}
+		current.Reset()
+		statementLine = 0
+	}
+
+	for i := 0; i < len(cypher); i++ {
+		c := cypher[i]
+
+		switch {
+		case c == '\n':
+			line++
+			atLineStart = true
+			current.WriteByte(c)
+			continue
+
+		case c == ' ' || c == '\t' || c == '\r':
+			current.WriteByte(c)
+			continue
+
+		case atLineStart && isDashComment(cypher[i:]), strings.HasPrefix(cypher[i:], "//"):
+			for i+1 < len(cypher) && cypher[i+1] != '\n' {
+				i++
+			}
+			continue
+
+		case strings.HasPrefix(cypher[i:], "/*"):
+			end := strings.Index(cypher[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated block comment at line %d", ErrInvalidMigrationFile, line)
+			}
+			comment := cypher[i : i+2+end+2]
+			line += strings.Count(comment, "\n")
+			i += len(comment) - 1
+			atLineStart = false
+			current.WriteByte(' ')
+			continue
+
+		case c == ';':
+			flush()
+			atLineStart = false
+			continue
+		}
+
+		if statementLine == 0 {
+			statementLine = line
+		}
+		atLineStart = false
+
+		if c == '\'' || c == '"' || c == '`' {
+			end, err := scanQuoted(cypher, i, line)
+			if err != nil {
+				return nil, err
+			}
+			literal := cypher[i : end+1]
+			line += strings.Count(literal, "\n")
+			current.WriteString(literal)
+			i = end
+			continue
+		}
+
+		current.WriteByte(c)
+	}
+
+	flush()
+
+	return statements, nil
+}
+
+func isDashComment(s string) bool {
+	if !strings.HasPrefix(s, "--") {
+		return false
+	}
+	return len(s) == 2 || s[2] == ' ' || s[2] == '\t' || s[2] == '\r' || s[2] == '\n'
+}
+
+func scanQuoted(cypher string, start int, line int) (int, error) {
+	quote := cypher[start]
+
+	for i := start + 1; i < len(cypher); i++ {
+		c := cypher[i]
+
+		if quote != '`' && c == '\\' {
+			i++
+			continue
+		}
+
+		if c != quote {
+			continue
+		}
+
+		if quote == '`' && i+1 < len(cypher) && cypher[i+1] == '`' {
+			i++
+			continue
+		}
+
+		return i, nil
+	}
+
+	if quote == '`' {
+		return 0, fmt.Errorf("%w: %w quoted identifier at line %d", ErrInvalidMigrationFile, errUnterminatedQuote, line)
+	}
+	return 0, fmt.Errorf("%w: %w string literal at line %d", ErrInvalidMigrationFile, errUnterminatedQuote, line)
+}
+
+func classifyStatement(text string) StatementKind {
+	if schemaStatementPattern.MatchString(text) {
+		return StatementSchema
+	}
+	return StatementData
+}
+
+func groupStatements(statements []Statement) [][]Statement {
+	var groups [][]Statement
+	for i, stmt := range statements {
+		if i == 0 || stmt.Kind != statements[i-1].Kind {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], stmt)
+	}
+	return groups
+}
@@ -0,0 +1,193 @@
+package neo4go
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name      string
+		cypher    string
+		firstLine int
+		want      []Statement
+		wantErr   error
+	}{
+		{
+			name:      "simple statements",
+			cypher:    "CREATE INDEX i1;\nCREATE INDEX i2;",
+			firstLine: 1,
+			want: []Statement{
+				{Text: "CREATE INDEX i1", Line: 1, Kind: StatementSchema},
+				{Text: "CREATE INDEX i2", Line: 2, Kind: StatementSchema},
+			},
+		},
+		{
+			name:      "semicolon inside single quoted string",
+			cypher:    "MATCH (u:User) SET u.bio = 'a;b';",
+			firstLine: 1,
+			want: []Statement{
+				{Text: "MATCH (u:User) SET u.bio = 'a;b'", Line: 1, Kind: StatementData},
+			},
+		},
+		{
+			name:      "semicolon inside double quoted string with escaped quote",
+			cypher:    `MATCH (u:User) SET u.bio = "say \";\" twice";`,
+			firstLine: 1,
+			want: []Statement{
+				{Text: `MATCH (u:User) SET u.bio = "say \";\" twice"`, Line: 1, Kind: StatementData},
+			},
+		},
+		{
+			name:      "semicolon inside backtick identifier",
+			cypher:    "MATCH (n:`odd;label`) RETURN n.`a``;b`;",
+			firstLine: 1,
+			want: []Statement{
+				{Text: "MATCH (n:`odd;label`) RETURN n.`a``;b`", Line: 1, Kind: StatementData},
+			},
+		},
+		{
+			name:      "line comments are stripped",
+			cypher:    "// setup; not a statement\nCREATE INDEX i1; // trailing; comment\n-- dash comment; too\nCREATE INDEX i2;",
+			firstLine: 10,
+			want: []Statement{
+				{Text: "CREATE INDEX i1", Line: 11, Kind: StatementSchema},
+				{Text: "CREATE INDEX i2", Line: 13, Kind: StatementSchema},
+			},
+		},
+		{
+			name:      "block comment spanning lines",
+			cypher:    "/* first;\nsecond; */\nCREATE INDEX i1;",
+			firstLine: 1,
+			want: []Statement{
+				{Text: "CREATE INDEX i1", Line: 3, Kind: StatementSchema},
+			},
+		},
+		{
+			name:      "undirected relationship is not a comment",
+			cypher:    "MATCH (a)\n--(b)\nRETURN a;",
+			firstLine: 1,
+			want: []Statement{
+				{Text: "MATCH (a)\n--(b)\nRETURN a", Line: 1, Kind: StatementData},
+			},
+		},
+		{
+			name:      "multi line string keeps line numbers in sync",
+			cypher:    "CREATE (:Note {text: 'one\ntwo'});\nCREATE INDEX i1;",
+			firstLine: 1,
+			want: []Statement{
+				{Text: "CREATE (:Note {text: 'one\ntwo'})", Line: 1, Kind: StatementData},
+				{Text: "CREATE INDEX i1", Line: 3, Kind: StatementSchema},
+			},
+		},
+		{
+			name:      "trailing statement without semicolon",
+			cypher:    "CREATE INDEX i1;\n\nCREATE INDEX i2",
+			firstLine: 1,
+			want: []Statement{
+				{Text: "CREATE INDEX i1", Line: 1, Kind: StatementSchema},
+				{Text: "CREATE INDEX i2", Line: 3, Kind: StatementSchema},
+			},
+		},
+		{
+			name:      "unterminated string literal",
+			cypher:    "CREATE INDEX i1;\nMATCH (u) SET u.name = 'oops;",
+			firstLine: 1,
+			wantErr:   ErrInvalidMigrationFile,
+		},
+		{
+			name:      "unterminated quoted identifier",
+			cypher:    "MATCH (n:`broken) RETURN n;",
+			firstLine: 1,
+			wantErr:   ErrInvalidMigrationFile,
+		},
+		{
+			name:      "unterminated block comment",
+			cypher:    "/* never closed\nCREATE INDEX i1;",
+			firstLine: 1,
+			wantErr:   ErrInvalidMigrationFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := splitStatements(tt.cypher, tt.firstLine)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(statements, tt.want) {
+				t.Errorf("expected statements:\n%#v\ngot:\n%#v", tt.want, statements)
+			}
+		})
+	}
+}
+
+func TestClassifyStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      StatementKind
+	}{
+		{statement: "CREATE CONSTRAINT user_id IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE", want: StatementSchema},
+		{statement: "create index user_email for (u:User) on (u.email)", want: StatementSchema},
+		{statement: "CREATE FULLTEXT INDEX names FOR (n:Person) ON EACH [n.name]", want: StatementSchema},
+		{statement: "CREATE OR REPLACE VECTOR INDEX embeddings FOR (n:Doc) ON (n.vector)", want: StatementSchema},
+		{statement: "DROP CONSTRAINT user_id IF EXISTS", want: StatementSchema},
+		{statement: "DROP\n  INDEX user_email", want: StatementSchema},
+		{statement: "CREATE (:Index {name: 'x'})", want: StatementData},
+		{statement: "MATCH (u:User) SET u.active = true", want: StatementData},
+		{statement: "CREATE (c:Constraint)", want: StatementData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			if got := classifyStatement(tt.statement); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGroupStatements(t *testing.T) {
+	schema := func(text string) Statement { return Statement{Text: text, Kind: StatementSchema} }
+	data := func(text string) Statement { return Statement{Text: text, Kind: StatementData} }
+
+	tests := []struct {
+		name       string
+		statements []Statement
+		want       [][]Statement
+	}{
+		{
+			name:       "no statements",
+			statements: nil,
+			want:       nil,
+		},
+		{
+			name:       "schema only",
+			statements: []Statement{schema("s1"), schema("s2")},
+			want:       [][]Statement{{schema("s1"), schema("s2")}},
+		},
+		{
+			name:       "schema then data then schema keeps order",
+			statements: []Statement{schema("s1"), data("d1"), data("d2"), schema("s2")},
+			want:       [][]Statement{{schema("s1")}, {data("d1"), data("d2")}, {schema("s2")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := groupStatements(tt.statements); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected groups:\n%#v\ngot:\n%#v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package neo4go
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	schemaMigrationLockID = "neo4go"
+
+	defaultLockTimeout = 5 * time.Minute
+	defaultLockLease   = 30 * time.Second
+	minLockLease       = time.Second
+	lockRetryInterval  = time.Second
+)
+
+func newLockOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(suffix))
+}
+
+func (m *migrator) withLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	lease := m.lockLease
+	if lease <= 0 {
+		lease = defaultLockLease
+	}
+
+	if err := m.acquireLock(ctx, lease); err != nil {
+		return err
+	}
+
+	lockCtx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		m.heartbeat(lockCtx, cancel, lease)
+	}()
+
+	err := fn(lockCtx)
+
+	lost := context.Cause(lockCtx)
+	cancel(nil)
+	<-done
+
+	if releaseErr := m.storage.ReleaseLock(context.WithoutCancel(ctx), m.lockOwner); releaseErr != nil {
+		m.logger.Warn("failed to release migration lock", "owner", m.lockOwner, "error", releaseErr)
+	}
+
+	if errors.Is(lost, ErrLockLost) {
+		if err != nil {
+			return fmt.Errorf("%w: %v", lost, err)
+		}
+		return lost
+	}
+
+	return err
+}
+
+func (m *migrator) acquireLock(ctx context.Context, lease time.Duration) error {
+	timeout := m.lockTimeout
+	if timeout <= 0 {
+		timeout = defaultLockTimeout
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		acquired, err := m.storage.AcquireLock(ctx, m.lockOwner, lease)
+		if err != nil {
+			return err
+		}
+
+		if acquired {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: waited %s", ErrLockTimeout, timeout)
+		}
+
+		m.logger.Info("waiting for migration lock", "owner", m.lockOwner)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+func (m *migrator) heartbeat(ctx context.Context, cancel context.CancelCauseFunc, lease time.Duration) {
+	interval := lease / 3
+	if interval <= 0 {
+		interval = lease
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastRefresh := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := m.storage.RefreshLock(ctx, m.lockOwner, lease)
+			if err == nil {
+				lastRefresh = time.Now()
+				continue
+			}
+
+			if errors.Is(err, ErrLockLost) {
+				m.logger.Error("migration lock lost", "owner", m.lockOwner)
+				cancel(err)
+				return
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if time.Since(lastRefresh) >= lease {
+				m.logger.Error("migration lock lease expired without a successful refresh", "owner", m.lockOwner, "error", err)
+				cancel(fmt.Errorf("%w: lease expired after failed refreshes: %v", ErrLockLost, err))
+				return
+			}
+
+			m.logger.Warn("failed to refresh migration lock", "owner", m.lockOwner, "error", err)
+		}
+	}
+}
@@ -3,33 +3,43 @@ package neo4go
 import (
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 )
 
 type defaultLogger struct {
 	logger *log.Logger
+	level  slog.Level
 }
 
-func newDefaultLogger() *defaultLogger {
+func newDefaultLogger(level slog.Level) *defaultLogger {
 	return &defaultLogger{
-		logger: log.New(os.Stdout, "[neo4go] ", log.LstdFlags),
+		logger: log.New(os.Stderr, "[neo4go] ", log.LstdFlags),
+		level:  level,
 	}
 }
 
 func (l *defaultLogger) Debug(msg string, args ...any) {
-	l.logger.Println("DEBUG:", l.formatMessage(msg, args...))
+	l.log(slog.LevelDebug, "DEBUG:", msg, args...)
 }
 
 func (l *defaultLogger) Info(msg string, args ...any) {
-	l.logger.Println("INFO:", l.formatMessage(msg, args...))
+	l.log(slog.LevelInfo, "INFO:", msg, args...)
 }
 
 func (l *defaultLogger) Warn(msg string, args ...any) {
-	l.logger.Println("WARN:", l.formatMessage(msg, args...))
+	l.log(slog.LevelWarn, "WARN:", msg, args...)
 }
 
 func (l *defaultLogger) Error(msg string, args ...any) {
-	l.logger.Println("ERROR:", l.formatMessage(msg, args...))
+	l.log(slog.LevelError, "ERROR:", msg, args...)
+}
+
+func (l *defaultLogger) log(level slog.Level, prefix string, msg string, args ...any) {
+	if level < l.level {
+		return
+	}
+	l.logger.Println(prefix, l.formatMessage(msg, args...))
 }
 
 func (l *defaultLogger) formatMessage(msg string, args ...any) string {
@@ -45,3 +55,41 @@ func (l *defaultLogger) formatMessage(msg string, args ...any) string {
 	}
 	return formatted
 }
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &slogLogger{logger: logger}
+}
+
+func (l *slogLogger) Debug(msg string, args ...any) {
+	l.logger.Debug(msg, args...)
+}
+
+func (l *slogLogger) Info(msg string, args ...any) {
+	l.logger.Info(msg, args...)
+}
+
+func (l *slogLogger) Warn(msg string, args ...any) {
+	l.logger.Warn(msg, args...)
+}
+
+func (l *slogLogger) Error(msg string, args ...any) {
+	l.logger.Error(msg, args...)
+}
+
+type nopLogger struct{}
+
+func NewNopLogger() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
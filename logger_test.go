@@ -0,0 +1,60 @@
+package neo4go
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDefaultLoggerLevel(t *testing.T) {
+	tests := []struct {
+		name   string
+		level  slog.Level
+		expect []string
+	}{
+		{name: "info by default", level: slog.LevelInfo, expect: []string{"INFO:", "WARN:", "ERROR:"}},
+		{name: "debug", level: slog.LevelDebug, expect: []string{"DEBUG:", "INFO:", "WARN:", "ERROR:"}},
+		{name: "warn", level: slog.LevelWarn, expect: []string{"WARN:", "ERROR:"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := newDefaultLogger(tt.level)
+			logger.logger = log.New(&buf, "", 0)
+
+			logger.Debug("message")
+			logger.Info("message", "version", 1)
+			logger.Warn("message")
+			logger.Error("message")
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != len(tt.expect) {
+				t.Fatalf("expected %d lines, got %q", len(tt.expect), buf.String())
+			}
+			for i, prefix := range tt.expect {
+				if !strings.HasPrefix(lines[i], prefix) {
+					t.Errorf("line %d: expected prefix %q, got %q", i, prefix, lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	logger.Info("skipped")
+	logger.Warn("lock refresh failed", "owner", "host-1")
+
+	output := buf.String()
+	if strings.Contains(output, "skipped") {
+		t.Errorf("expected info message to be filtered, got %q", output)
+	}
+	if !strings.Contains(output, "lock refresh failed") || !strings.Contains(output, "owner=host-1") {
+		t.Errorf("expected warn message with attributes, got %q", output)
+	}
+}
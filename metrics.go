@@ -0,0 +1,146 @@
+package neo4go
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type prometheusMetrics struct {
+	applied           *prometheus.CounterVec
+	rolledBack        *prometheus.CounterVec
+	failed            *prometheus.CounterVec
+	migrationDuration *prometheus.HistogramVec
+	statementDuration *prometheus.HistogramVec
+	version           *prometheus.GaugeVec
+	pending           *prometheus.GaugeVec
+}
+
+func NewPrometheusMetrics(registerer prometheus.Registerer) (Metrics, error) {
+	m := &prometheusMetrics{
+		applied: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "neo4go",
+			Name:      "migrations_applied_total",
+			Help:      "Number of migrations applied.",
+		}, []string{"database"}),
+		rolledBack: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "neo4go",
+			Name:      "migrations_rolled_back_total",
+			Help:      "Number of migrations rolled back.",
+		}, []string{"database"}),
+		failed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "neo4go",
+			Name:      "migrations_failed_total",
+			Help:      "Number of migrations that failed, by direction.",
+		}, []string{"database", "direction"}),
+		migrationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "neo4go",
+			Name:      "migration_duration_seconds",
+			Help:      "Duration of each migration, by direction.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 4, 8),
+		}, []string{"database", "direction"}),
+		statementDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "neo4go",
+			Name:      "statement_duration_seconds",
+			Help:      "Duration of each migration statement, by kind.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+		}, []string{"database", "kind"}),
+		version: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "neo4go",
+			Name:      "schema_version",
+			Help:      "Current schema version recorded in the database.",
+		}, []string{"database"}),
+		pending: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "neo4go",
+			Name:      "pending_migrations",
+			Help:      "Number of known migrations that are not applied.",
+		}, []string{"database"}),
+	}
+
+	collectors := []prometheus.Collector{
+		m.applied, m.rolledBack, m.failed,
+		m.migrationDuration, m.statementDuration,
+		m.version, m.pending,
+	}
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func (p *prometheusMetrics) ObserveMigration(database string, result MigrationResult) {
+	direction := string(result.Direction)
+	p.migrationDuration.WithLabelValues(database, direction).Observe(result.Duration.Seconds())
+
+	switch {
+	case result.Error != nil:
+		p.failed.WithLabelValues(database, direction).Inc()
+	case result.Direction == DirectionDown:
+		p.rolledBack.WithLabelValues(database).Inc()
+	default:
+		p.applied.WithLabelValues(database).Inc()
+	}
+}
+
+func (p *prometheusMetrics) ObserveStatement(database string, kind StatementKind, duration time.Duration) {
+	p.statementDuration.WithLabelValues(database, string(kind)).Observe(duration.Seconds())
+}
+
+func (p *prometheusMetrics) SetSchemaState(database string, version, pending int) {
+	p.version.WithLabelValues(database).Set(float64(version))
+	p.pending.WithLabelValues(database).Set(float64(pending))
+}
+
+func (m *migrator) observeMigration(result MigrationResult) {
+	if m.metrics != nil {
+		m.metrics.ObserveMigration(m.database, result)
+	}
+}
+
+func (m *migrator) observeStatement(kind StatementKind, start time.Time) {
+	if m.metrics != nil {
+		m.metrics.ObserveStatement(m.database, kind, time.Since(start))
+	}
+}
+
+func (m *migrator) updateSchemaState(ctx context.Context) {
+	if m.metrics == nil {
+		return
+	}
+
+	applied, err := m.storage.GetAppliedMigrations(context.WithoutCancel(ctx))
+	if err != nil {
+		m.logger.Warn("failed to update schema metrics", "error", err)
+		return
+	}
+
+	version, pending := schemaState(m.migrations, applied)
+	m.metrics.SetSchemaState(m.database, version, pending)
+}
+
+func schemaState(migrations []Migration, applied []MigrationRecord) (int, int) {
+	version := 0
+	appliedVersions := make(map[int]bool, len(applied))
+	for _, record := range applied {
+		if record.Dirty {
+			continue
+		}
+		appliedVersions[record.Version] = true
+		if record.Version > version {
+			version = record.Version
+		}
+	}
+
+	pending := 0
+	for _, migration := range migrations {
+		if !appliedVersions[migration.Version] {
+			pending++
+		}
+	}
+
+	return version, pending
+}
@@ -0,0 +1,86 @@
+package neo4go
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	ctx := context.Background()
+	storage := newMockStorage()
+
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "indexes", UpSQL: "CREATE INDEX i1;", DownSQL: "DROP INDEX i1;", Checksum: "def"},
+		{Version: 3, Name: "more", UpSQL: "CREATE INDEX i2;", DownSQL: "DROP INDEX i2;", Checksum: "ghi"},
+	}
+
+	storage.RecordFunc = func(ctx context.Context, migration Migration) error {
+		if migration.Version == 3 {
+			return errors.New("record failed")
+		}
+		storage.appliedMigrations[migration.Version] = MigrationRecord{Version: migration.Version, Checksum: migration.Checksum}
+		return nil
+	}
+
+	registry := prometheus.NewRegistry()
+	metrics, err := NewPrometheusMetrics(registry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m := &migrator{
+		driver:     nil,
+		storage:    storage,
+		migrations: migrations,
+		database:   "neo4j",
+		logger:     newMockLogger(),
+		metrics:    metrics,
+	}
+
+	p := metrics.(*prometheusMetrics)
+
+	if err := m.Up(ctx); err == nil {
+		t.Fatal("expected version 3 to fail")
+	}
+
+	expect := func(name string, collector prometheus.Collector, want float64) {
+		t.Helper()
+		if got := testutil.ToFloat64(collector); got != want {
+			t.Errorf("expected %s = %v, got %v", name, want, got)
+		}
+	}
+
+	expect("applied", p.applied.WithLabelValues("neo4j"), 2)
+	expect("failed up", p.failed.WithLabelValues("neo4j", "up"), 1)
+	expect("schema version", p.version.WithLabelValues("neo4j"), 2)
+	expect("pending", p.pending.WithLabelValues("neo4j"), 1)
+
+	if count := testutil.CollectAndCount(p.migrationDuration); count != 1 {
+		t.Errorf("expected 1 migration duration series, got %d", count)
+	}
+
+	if err := m.DownTo(ctx, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect("rolled back", p.rolledBack.WithLabelValues("neo4j"), 2)
+	expect("schema version after rollback", p.version.WithLabelValues("neo4j"), 0)
+	expect("pending after rollback", p.pending.WithLabelValues("neo4j"), 3)
+
+	storage.appliedMigrations[1] = MigrationRecord{Version: 1, Checksum: "abc"}
+	if _, err := m.Status(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect("schema version from status", p.version.WithLabelValues("neo4j"), 1)
+	expect("pending from status", p.pending.WithLabelValues("neo4j"), 2)
+
+	if _, err := NewPrometheusMetrics(registry); err == nil {
+		t.Error("expected registering twice to fail")
+	}
+}
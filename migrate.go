@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"log/slog"
 	"os"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Config struct {
@@ -17,6 +20,16 @@ type Config struct {
 	MigrationsDir string
 	MigrationsFS  fs.FS
 	Logger        Logger
+	LogLevel      slog.Level
+	LockTimeout   time.Duration
+	LockLease     time.Duration
+
+	ChecksumPolicy ChecksumPolicy
+	Hooks          Hooks
+
+	TracerProvider   trace.TracerProvider
+	RedactStatements bool
+	Metrics          Metrics
 }
 
 func New(cfg Config) (Migrator, error) {
@@ -47,7 +60,7 @@ func NewWithDriver(driver neo4j.DriverWithContext, cfg Config) (Migrator, error)
 
 	logger := cfg.Logger
 	if logger == nil {
-		logger = newDefaultLogger()
+		logger = newDefaultLogger(cfg.LogLevel)
 	}
 
 	filesystem := cfg.MigrationsFS
@@ -72,6 +85,17 @@ func NewWithDriver(driver neo4j.DriverWithContext, cfg Config) (Migrator, error)
 		return nil, err
 	}
 
+	m.lockTimeout = cfg.LockTimeout
+	m.lockLease = cfg.LockLease
+	m.checksumPolicy = cfg.ChecksumPolicy
+	m.hooks = cfg.Hooks
+	m.redactStatements = cfg.RedactStatements
+	m.metrics = cfg.Metrics
+
+	if cfg.TracerProvider != nil {
+		m.tracer = cfg.TracerProvider.Tracer(tracerName)
+	}
+
 	return m, nil
 }
 
@@ -92,5 +116,13 @@ func validateConfig(cfg Config) error {
 		return fmt.Errorf("%w: either MigrationsDir or MigrationsFS must be provided", ErrInvalidConfig)
 	}
 
+	if cfg.LockLease < 0 || (cfg.LockLease > 0 && cfg.LockLease < minLockLease) {
+		return fmt.Errorf("%w: LockLease must be at least %s", ErrInvalidConfig, minLockLease)
+	}
+
+	if cfg.LockTimeout < 0 {
+		return fmt.Errorf("%w: LockTimeout must not be negative", ErrInvalidConfig)
+	}
+
 	return nil
 }
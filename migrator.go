@@ -2,11 +2,14 @@ package neo4go
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
-	"strings"
+	"math"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type migrator struct {
@@ -16,11 +19,29 @@ type migrator struct {
 	migrations []Migration
 	database   string
 	logger     Logger
+
+	lockOwner   string
+	lockTimeout time.Duration
+	lockLease   time.Duration
+
+	checksumPolicy ChecksumPolicy
+	hooks          Hooks
+
+	tracer           trace.Tracer
+	redactStatements bool
+	metrics          Metrics
 }
 
 func newMigrator(driver neo4j.DriverWithContext, storage Storage, filesystem fs.FS, migrationsDir string, database string, logger Logger) (*migrator, error) {
 	p := newParser(filesystem)
-	migrations, err := p.parseMigrations(migrationsDir)
+	fileMigrations, err := p.parseMigrations(migrationsDir)
+
+	goMigrations := registeredGoMigrations()
+	if err != nil && !(errors.Is(err, ErrNoMigrations) && len(goMigrations) > 0) {
+		return nil, err
+	}
+
+	migrations, err := mergeMigrations(fileMigrations, goMigrations)
 	if err != nil {
 		return nil, err
 	}
@@ -32,99 +53,193 @@ func newMigrator(driver neo4j.DriverWithContext, storage Storage, filesystem fs.
 		migrations: migrations,
 		database:   database,
 		logger:     logger,
+		lockOwner:  newLockOwner(),
 	}, nil
 }
 
 func (m *migrator) Up(ctx context.Context) error {
+	_, err := m.UpWithResults(ctx)
+	return err
+}
+
+func (m *migrator) UpWithResults(ctx context.Context) (results []MigrationResult, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.up")
+	defer func() { endSpan(span, err) }()
+
 	if err := m.storage.Init(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
-	applied, err := m.storage.GetAppliedMigrations(ctx)
-	if err != nil {
-		return err
-	}
+	return m.run(ctx, DirectionUp, func(ctx context.Context) ([]Migration, error) {
+		return m.planUp(ctx, math.MaxInt)
+	})
+}
 
-	appliedVersions := make(map[int]bool)
-	for _, record := range applied {
-		appliedVersions[record.Version] = true
+func (m *migrator) Down(ctx context.Context) error {
+	_, err := m.DownWithResults(ctx)
+	return err
+}
+
+func (m *migrator) DownWithResults(ctx context.Context) (results []MigrationResult, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.down")
+	defer func() { endSpan(span, err) }()
+
+	if err := m.storage.Init(ctx); err != nil {
+		return nil, err
 	}
 
-	for _, migration := range m.migrations {
-		if appliedVersions[migration.Version] {
-			m.logger.Debug("skipping already applied migration", "version", migration.Version, "name", migration.Name)
-			continue
-		}
+	return m.run(ctx, DirectionDown, func(ctx context.Context) ([]Migration, error) {
+		return m.planDownOne(ctx)
+	})
+}
 
-		m.logger.Info("applying migration", "version", migration.Version, "name", migration.Name)
+func (m *migrator) UpTo(ctx context.Context, targetVersion int) error {
+	_, err := m.UpToWithResults(ctx, targetVersion)
+	return err
+}
 
-		if err := m.executeMigration(ctx, migration.UpSQL); err != nil {
-			return fmt.Errorf("failed to apply migration %d: %w", migration.Version, err)
-		}
+func (m *migrator) UpToWithResults(ctx context.Context, targetVersion int) (results []MigrationResult, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.up_to", attrTargetVersion.Int(targetVersion))
+	defer func() { endSpan(span, err) }()
 
-		if err := m.storage.RecordMigration(ctx, migration); err != nil {
-			return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
-		}
+	if err := m.storage.Init(ctx); err != nil {
+		return nil, err
+	}
 
-		m.logger.Info("successfully applied migration", "version", migration.Version, "name", migration.Name)
+	if targetVersion < 0 {
+		return nil, ErrInvalidVersion
 	}
 
-	return nil
+	return m.run(ctx, DirectionUp, func(ctx context.Context) ([]Migration, error) {
+		return m.planUp(ctx, targetVersion)
+	})
 }
 
-func (m *migrator) Down(ctx context.Context) error {
+func (m *migrator) DownTo(ctx context.Context, targetVersion int) error {
+	_, err := m.DownToWithResults(ctx, targetVersion)
+	return err
+}
+
+func (m *migrator) DownToWithResults(ctx context.Context, targetVersion int) (results []MigrationResult, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.down_to", attrTargetVersion.Int(targetVersion))
+	defer func() { endSpan(span, err) }()
+
 	if err := m.storage.Init(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
-	currentVersion, err := m.storage.GetCurrentVersion(ctx)
-	if err != nil {
-		return err
+	if targetVersion < 0 {
+		return nil, ErrInvalidVersion
 	}
 
-	if currentVersion == 0 {
-		m.logger.Info("no migrations to rollback")
-		return nil
+	return m.run(ctx, DirectionDown, func(ctx context.Context) ([]Migration, error) {
+		return m.planDown(ctx, targetVersion)
+	})
+}
+
+func (m *migrator) Redo(ctx context.Context) (results []MigrationResult, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.redo")
+	defer func() { endSpan(span, err) }()
+
+	if err := m.storage.Init(ctx); err != nil {
+		return nil, err
 	}
 
-	var targetMigration *Migration
-	for _, migration := range m.migrations {
-		if migration.Version == currentVersion {
-			targetMigration = &migration
-			break
+	return m.runLocked(ctx, func(ctx context.Context) ([]MigrationResult, error) {
+		migrations, err := m.planRedo(ctx)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	if targetMigration == nil {
-		return fmt.Errorf("%w: version %d", ErrMigrationNotFound, currentVersion)
-	}
+		results, err := m.runEach(ctx, migrations, DirectionDown)
+		if err != nil || len(migrations) == 0 {
+			return results, err
+		}
 
-	m.logger.Info("rolling back migration", "version", targetMigration.Version, "name", targetMigration.Name)
+		upResults, err := m.runEach(ctx, migrations, DirectionUp)
+		return append(results, upResults...), err
+	})
+}
 
-	if err := m.executeMigration(ctx, targetMigration.DownSQL); err != nil {
-		return fmt.Errorf("failed to rollback migration %d: %w", targetMigration.Version, err)
-	}
+func (m *migrator) Reset(ctx context.Context) (results []MigrationResult, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.reset")
+	defer func() { endSpan(span, err) }()
 
-	if err := m.storage.RemoveMigration(ctx, targetMigration.Version); err != nil {
-		return fmt.Errorf("failed to remove migration record %d: %w", targetMigration.Version, err)
+	if err := m.storage.Init(ctx); err != nil {
+		return nil, err
 	}
 
-	m.logger.Info("successfully rolled back migration", "version", targetMigration.Version, "name", targetMigration.Name)
-	return nil
+	return m.run(ctx, DirectionDown, func(ctx context.Context) ([]Migration, error) {
+		return m.planDown(ctx, 0)
+	})
 }
 
-func (m *migrator) UpTo(ctx context.Context, targetVersion int) error {
+func (m *migrator) Plan(ctx context.Context, direction Direction, targetVersion int) (plan []PlannedMigration, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.plan", attrDirection.String(string(direction)), attrTargetVersion.Int(targetVersion))
+	defer func() { endSpan(span, err) }()
+
 	if err := m.storage.Init(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
 	if targetVersion < 0 {
-		return ErrInvalidVersion
+		return nil, ErrInvalidVersion
 	}
 
+	var migrations []Migration
+	switch direction {
+	case DirectionUp:
+		migrations, err = m.planUp(ctx, targetVersion)
+	case DirectionDown:
+		migrations, err = m.planDown(ctx, targetVersion)
+	default:
+		return nil, fmt.Errorf("unknown direction %q", direction)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	plan = make([]PlannedMigration, 0, len(migrations))
+	for _, migration := range migrations {
+		statements, err := migrationStatements(migration, direction)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan migration %d: %w", migration.Version, err)
+		}
+
+		plan = append(plan, PlannedMigration{
+			Version:    migration.Version,
+			Name:       migration.Name,
+			Direction:  direction,
+			TxMode:     migration.TxMode,
+			Statements: statements,
+			Go:         migration.goFuncs != nil,
+		})
+	}
+
+	return plan, nil
+}
+
+func (m *migrator) loadApplied(ctx context.Context) ([]MigrationRecord, error) {
 	applied, err := m.storage.GetAppliedMigrations(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := checkDirty(applied); err != nil {
+		return nil, err
+	}
+
+	if err := m.verifyChecksums(applied); err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+func (m *migrator) planUp(ctx context.Context, targetVersion int) ([]Migration, error) {
+	applied, err := m.loadApplied(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	appliedVersions := make(map[int]bool)
@@ -132,6 +247,7 @@ func (m *migrator) UpTo(ctx context.Context, targetVersion int) error {
 		appliedVersions[record.Version] = true
 	}
 
+	var pending []Migration
 	for _, migration := range m.migrations {
 		if migration.Version > targetVersion {
 			break
@@ -142,72 +258,115 @@ func (m *migrator) UpTo(ctx context.Context, targetVersion int) error {
 			continue
 		}
 
-		m.logger.Info("applying migration", "version", migration.Version, "name", migration.Name)
+		pending = append(pending, migration)
+	}
+
+	return pending, nil
+}
+
+func (m *migrator) planDown(ctx context.Context, targetVersion int) ([]Migration, error) {
+	applied, err := m.loadApplied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for i := len(applied) - 1; i >= 0; i-- {
+		record := applied[i]
 
-		if err := m.executeMigration(ctx, migration.UpSQL); err != nil {
-			return fmt.Errorf("failed to apply migration %d: %w", migration.Version, err)
+		if record.Version <= targetVersion {
+			break
 		}
 
-		if err := m.storage.RecordMigration(ctx, migration); err != nil {
-			return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		migration, err := m.findMigration(record.Version)
+		if err != nil {
+			return nil, err
 		}
 
-		m.logger.Info("successfully applied migration", "version", migration.Version, "name", migration.Name)
+		pending = append(pending, migration)
 	}
 
-	return nil
+	if err := checkReversible(pending); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
 }
 
-func (m *migrator) DownTo(ctx context.Context, targetVersion int) error {
-	if err := m.storage.Init(ctx); err != nil {
-		return err
+func (m *migrator) planDownOne(ctx context.Context) ([]Migration, error) {
+	if _, err := m.loadApplied(ctx); err != nil {
+		return nil, err
 	}
 
-	if targetVersion < 0 {
-		return ErrInvalidVersion
+	currentVersion, err := m.storage.GetCurrentVersion(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	applied, err := m.storage.GetAppliedMigrations(ctx)
+	if currentVersion == 0 {
+		m.logger.Info("no migrations to rollback")
+		return nil, nil
+	}
+
+	migration, err := m.findMigration(currentVersion)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for i := len(applied) - 1; i >= 0; i-- {
-		record := applied[i]
+	if err := checkReversible([]Migration{migration}); err != nil {
+		return nil, err
+	}
 
-		if record.Version <= targetVersion {
-			break
-		}
+	return []Migration{migration}, nil
+}
 
-		var targetMigration *Migration
-		for _, migration := range m.migrations {
-			if migration.Version == record.Version {
-				targetMigration = &migration
-				break
-			}
-		}
+func (m *migrator) planRedo(ctx context.Context) ([]Migration, error) {
+	applied, err := m.storage.GetAppliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		if targetMigration == nil {
-			return fmt.Errorf("%w: version %d", ErrMigrationNotFound, record.Version)
-		}
+	if err := checkDirty(applied); err != nil {
+		return nil, err
+	}
 
-		m.logger.Info("rolling back migration", "version", targetMigration.Version, "name", targetMigration.Name)
+	currentVersion, err := m.storage.GetCurrentVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		if err := m.executeMigration(ctx, targetMigration.DownSQL); err != nil {
-			return fmt.Errorf("failed to rollback migration %d: %w", targetMigration.Version, err)
-		}
+	if currentVersion == 0 {
+		m.logger.Info("no migrations to redo")
+		return nil, nil
+	}
 
-		if err := m.storage.RemoveMigration(ctx, targetMigration.Version); err != nil {
-			return fmt.Errorf("failed to remove migration record %d: %w", targetMigration.Version, err)
+	var previous []MigrationRecord
+	for _, record := range applied {
+		if record.Version != currentVersion {
+			previous = append(previous, record)
 		}
+	}
 
-		m.logger.Info("successfully rolled back migration", "version", targetMigration.Version, "name", targetMigration.Name)
+	if err := m.verifyChecksums(previous); err != nil {
+		return nil, err
 	}
 
-	return nil
+	migration, err := m.findMigration(currentVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkReversible([]Migration{migration}); err != nil {
+		return nil, err
+	}
+
+	return []Migration{migration}, nil
 }
 
-func (m *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+func (m *migrator) Status(ctx context.Context) (statuses []MigrationStatus, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.status")
+	defer func() { endSpan(span, err) }()
+
 	if err := m.storage.Init(ctx); err != nil {
 		return nil, err
 	}
@@ -217,26 +376,35 @@ func (m *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
 		return nil, err
 	}
 
+	if m.metrics != nil {
+		version, pending := schemaState(m.migrations, applied)
+		m.metrics.SetSchemaState(m.database, version, pending)
+	}
+
 	appliedMap := make(map[int]MigrationRecord)
 	for _, record := range applied {
 		appliedMap[record.Version] = record
 	}
 
-	var statuses []MigrationStatus
 	for _, migration := range m.migrations {
 		status := MigrationStatus{
-			Version:  migration.Version,
-			Name:     migration.Name,
-			Applied:  false,
-			Checksum: migration.Checksum,
+			Version:    migration.Version,
+			Name:       migration.Name,
+			Applied:    false,
+			Checksum:   migration.Checksum,
+			Reversible: !migration.Irreversible,
 		}
 
 		if record, exists := appliedMap[migration.Version]; exists {
-			status.Applied = true
+			status.Applied = !record.Dirty
 			appliedAt := record.AppliedAt
 			status.AppliedAt = &appliedAt
+			status.Baselined = record.Baselined
+			status.Dirty = record.Dirty
+			status.Error = record.Error
+			status.FailedStatement = record.FailedStatement
 
-			if record.Checksum != migration.Checksum {
+			if record.Checksum != migration.Checksum && m.checksumPolicy != ChecksumIgnore {
 				m.logger.Warn("checksum mismatch", "version", migration.Version, "name", migration.Name)
 			}
 		}
@@ -247,7 +415,10 @@ func (m *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
 	return statuses, nil
 }
 
-func (m *migrator) Version(ctx context.Context) (int, error) {
+func (m *migrator) Version(ctx context.Context) (version int, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.version")
+	defer func() { endSpan(span, err) }()
+
 	if err := m.storage.Init(ctx); err != nil {
 		return 0, err
 	}
@@ -255,43 +426,472 @@ func (m *migrator) Version(ctx context.Context) (int, error) {
 	return m.storage.GetCurrentVersion(ctx)
 }
 
+func (m *migrator) Force(ctx context.Context, version int, applied bool) (err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.force", attrVersion.Int(version))
+	defer func() { endSpan(span, err) }()
+
+	if err := m.storage.Init(ctx); err != nil {
+		return err
+	}
+
+	migration, err := m.findMigration(version)
+	if err != nil {
+		return err
+	}
+
+	return m.withLock(ctx, func(ctx context.Context) error {
+		if applied {
+			if err := m.storage.RecordMigration(ctx, migration); err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", version, err)
+			}
+
+			m.logger.Info("forced migration as applied", "version", version, "name", migration.Name)
+			return nil
+		}
+
+		if err := m.storage.RemoveMigration(ctx, version); err != nil {
+			return fmt.Errorf("failed to remove migration record %d: %w", version, err)
+		}
+
+		m.logger.Info("forced migration as not applied", "version", version, "name", migration.Name)
+		return nil
+	})
+}
+
+func (m *migrator) Repair(ctx context.Context) (repaired []int, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.repair")
+	defer func() { endSpan(span, err) }()
+
+	if err := m.storage.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	err = m.withLock(ctx, func(ctx context.Context) error {
+		applied, err := m.storage.GetAppliedMigrations(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range applied {
+			if record.Dirty {
+				continue
+			}
+
+			migration, err := m.findMigration(record.Version)
+			if err != nil {
+				m.logger.Warn("skipping applied migration without a file", "version", record.Version, "name", record.Name)
+				continue
+			}
+
+			if record.Checksum == migration.Checksum {
+				continue
+			}
+
+			if err := m.storage.UpdateChecksum(ctx, record.Version, migration.Checksum); err != nil {
+				return fmt.Errorf("failed to update checksum of migration %d: %w", record.Version, err)
+			}
+
+			m.logger.Info("repaired migration checksum", "version", record.Version, "name", migration.Name)
+			repaired = append(repaired, record.Version)
+		}
+
+		return nil
+	})
+
+	return repaired, err
+}
+
+func (m *migrator) Baseline(ctx context.Context, version int) (baselined []int, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.baseline", attrTargetVersion.Int(version))
+	defer func() { endSpan(span, err) }()
+
+	if err := m.storage.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := m.findMigration(version); err != nil {
+		return nil, err
+	}
+
+	err = m.withLock(ctx, func(ctx context.Context) error {
+		applied, err := m.storage.GetAppliedMigrations(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := checkDirty(applied); err != nil {
+			return err
+		}
+
+		appliedMap := make(map[int]bool)
+		for _, record := range applied {
+			appliedMap[record.Version] = true
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+
+			if appliedMap[migration.Version] {
+				m.logger.Debug("skipping already applied migration", "version", migration.Version, "name", migration.Name)
+				continue
+			}
+
+			if err := m.storage.RecordBaseline(ctx, migration); err != nil {
+				return fmt.Errorf("failed to record baseline for migration %d: %w", migration.Version, err)
+			}
+
+			m.logger.Info("baselined migration", "version", migration.Version, "name", migration.Name)
+			baselined = append(baselined, migration.Version)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.updateSchemaState(ctx)
+	return baselined, nil
+}
+
 func (m *migrator) Close() error {
 	return m.storage.Close()
 }
 
-func (m *migrator) executeMigration(ctx context.Context, sql string) error {
-	if m.driver == nil {
+func (m *migrator) migrate(ctx context.Context, migration Migration, direction Direction) (MigrationResult, error) {
+	result := MigrationResult{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		Direction: direction,
+	}
+
+	ctx, span := m.startMigrationSpan(ctx, migration, direction)
+
+	start := time.Now()
+	err := m.applyMigration(ctx, migration, direction, &result)
+	result.Duration = time.Since(start)
+	result.Error = err
+
+	span.SetAttributes(attrStatements.Int(result.Statements))
+	endSpan(span, err)
+	m.observeMigration(result)
+
+	return result, err
+}
+
+func (m *migrator) applyMigration(ctx context.Context, migration Migration, direction Direction, result *MigrationResult) error {
+	if direction == DirectionDown {
+		m.logger.Info("rolling back migration", "version", migration.Version, "name", migration.Name)
+	} else {
+		m.logger.Info("applying migration", "version", migration.Version, "name", migration.Name)
+	}
+
+	record := func(ctx context.Context, tx neo4j.ManagedTransaction) error {
+		if direction == DirectionDown {
+			return m.storage.RemoveMigrationTx(ctx, tx, migration.Version)
+		}
+		return m.storage.RecordMigrationTx(ctx, tx, migration)
+	}
+
+	exec, err := m.executeMigration(ctx, migration, direction, record)
+	result.Statements = exec.committed
+	result.Counters = exec.counters
+	if err != nil {
+		if exec.dirty {
+			m.logger.Error("migration partially applied", "version", migration.Version, "name", migration.Name, "failed_statement", exec.failed)
+
+			if dirtyErr := m.storage.MarkDirty(context.WithoutCancel(ctx), migration, exec.failed, err.Error()); dirtyErr != nil {
+				m.logger.Error("failed to mark migration dirty", "version", migration.Version, "error", dirtyErr)
+			}
+		}
+
+		if direction == DirectionDown {
+			return fmt.Errorf("failed to rollback migration %d: %w", migration.Version, err)
+		}
+		return fmt.Errorf("failed to apply migration %d: %w", migration.Version, err)
+	}
+
+	if direction == DirectionDown {
+		if !exec.recorded {
+			if err := m.storage.RemoveMigration(ctx, migration.Version); err != nil {
+				return fmt.Errorf("failed to remove migration record %d: %w", migration.Version, err)
+			}
+		}
+
+		m.logger.Info("successfully rolled back migration", "version", migration.Version, "name", migration.Name)
 		return nil
 	}
 
+	if !exec.recorded {
+		if err := m.storage.RecordMigration(ctx, migration); err != nil {
+			return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+	}
+
+	m.logger.Info("successfully applied migration", "version", migration.Version, "name", migration.Name)
+	return nil
+}
+
+type execution struct {
+	recorded  bool
+	committed int
+	failed    int
+	dirty     bool
+	counters  Counters
+}
+
+func (m *migrator) executeMigration(ctx context.Context, migration Migration, direction Direction, record func(context.Context, neo4j.ManagedTransaction) error) (execution, error) {
+	var exec execution
+
+	statements, err := migrationStatements(migration, direction)
+	if err != nil {
+		return exec, err
+	}
+
+	if m.driver == nil {
+		return exec, nil
+	}
+
 	session := m.driver.NewSession(ctx, neo4j.SessionConfig{
 		AccessMode:   neo4j.AccessModeWrite,
 		DatabaseName: m.database,
 	})
 	defer session.Close(ctx)
 
-	statements := m.splitStatements(sql)
+	if migration.goFuncs != nil {
+		return m.executeGoMigration(ctx, session, migration, direction, record)
+	}
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-		for _, stmt := range statements {
-			stmt = strings.TrimSpace(stmt)
-			if stmt == "" {
-				continue
-			}
+	if migration.TxMode == NoTransaction {
+		m.logger.Debug("running statements without a transaction", "version", migration.Version)
 
-			m.logger.Debug("executing statement", "statement", stmt)
+		for i, stmt := range statements {
+			m.logger.Debug("executing statement", "statement", stmt.Text, "line", stmt.Line, "kind", stmt.Kind)
 
-			_, err := tx.Run(ctx, stmt, nil)
+			stmtCtx, span := m.startStatementSpan(ctx, migration, stmt)
+			start := time.Now()
+			result, err := session.Run(stmtCtx, stmt.Text, nil)
+			if err == nil {
+				var summary neo4j.ResultSummary
+				if summary, err = result.Consume(stmtCtx); err == nil {
+					exec.counters.add(summary.Counters())
+				}
+			}
+			endSpan(span, err)
+			m.observeStatement(stmt.Kind, start)
 			if err != nil {
-				return nil, fmt.Errorf("%w: %v", ErrTransactionFailed, err)
+				exec.failed = i + 1
+				exec.dirty = exec.committed > 0
+				return exec, fmt.Errorf("%w: statement %d at line %d: %v", ErrTransactionFailed, i+1, stmt.Line, err)
 			}
+
+			exec.committed++
 		}
-		return nil, nil
+
+		return exec, nil
+	}
+
+	batches := transactionBatches(statements, migration.TxMode)
+	if len(batches) > 1 {
+		m.logger.Debug("running migration in multiple transactions", "version", migration.Version, "transactions", len(batches))
+	}
+
+	for b, batch := range batches {
+		recordInBatch := b == len(batches)-1 && batch[len(batch)-1].Kind == StatementData
+
+		var counters Counters
+		_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			counters = Counters{}
+
+			for i, stmt := range batch {
+				m.logger.Debug("executing statement", "statement", stmt.Text, "line", stmt.Line, "kind", stmt.Kind)
+
+				stmtCtx, span := m.startStatementSpan(ctx, migration, stmt)
+				start := time.Now()
+				result, err := tx.Run(stmtCtx, stmt.Text, nil)
+				if err == nil {
+					var summary neo4j.ResultSummary
+					if summary, err = result.Consume(stmtCtx); err == nil {
+						counters.add(summary.Counters())
+					}
+				}
+				endSpan(span, err)
+				m.observeStatement(stmt.Kind, start)
+				if err != nil {
+					exec.failed = exec.committed + i + 1
+					return nil, fmt.Errorf("%w: statement %d at line %d: %v", ErrTransactionFailed, exec.failed, stmt.Line, err)
+				}
+			}
+
+			if recordInBatch {
+				return nil, record(ctx, tx)
+			}
+			return nil, nil
+		})
+		if err != nil {
+			exec.dirty = exec.committed > 0
+			return exec, err
+		}
+
+		exec.committed += len(batch)
+		exec.counters.merge(counters)
+		exec.recorded = recordInBatch
+	}
+
+	return exec, nil
+}
+
+func (m *migrator) executeGoMigration(ctx context.Context, session neo4j.SessionWithContext, migration Migration, direction Direction, record func(context.Context, neo4j.ManagedTransaction) error) (execution, error) {
+	var exec execution
+
+	fn, fnNoTx := migration.goFuncs.up, migration.goFuncs.upNoTx
+	if direction == DirectionDown {
+		fn, fnNoTx = migration.goFuncs.down, migration.goFuncs.downNoTx
+	}
+
+	if migration.TxMode == NoTransaction {
+		if fnNoTx == nil {
+			return exec, nil
+		}
+
+		m.logger.Debug("running Go migration without a transaction", "version", migration.Version)
+
+		if err := fnNoTx(ctx, session); err != nil {
+			exec.failed = 1
+			exec.dirty = true
+			return exec, fmt.Errorf("%w: %w", ErrTransactionFailed, err)
+		}
+		return exec, nil
+	}
+
+	m.logger.Debug("running Go migration", "version", migration.Version)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		if fn != nil {
+			if err := fn(ctx, tx); err != nil {
+				return nil, err
+			}
+		}
+		return nil, record(ctx, tx)
+	})
+	if err != nil {
+		return exec, fmt.Errorf("%w: %w", ErrTransactionFailed, err)
+	}
+
+	exec.recorded = true
+	return exec, nil
+}
+
+func (c *Counters) add(counters neo4j.Counters) {
+	c.merge(Counters{
+		NodesCreated:         counters.NodesCreated(),
+		NodesDeleted:         counters.NodesDeleted(),
+		RelationshipsCreated: counters.RelationshipsCreated(),
+		RelationshipsDeleted: counters.RelationshipsDeleted(),
+		PropertiesSet:        counters.PropertiesSet(),
+		LabelsAdded:          counters.LabelsAdded(),
+		LabelsRemoved:        counters.LabelsRemoved(),
+		IndexesAdded:         counters.IndexesAdded(),
+		IndexesRemoved:       counters.IndexesRemoved(),
+		ConstraintsAdded:     counters.ConstraintsAdded(),
+		ConstraintsRemoved:   counters.ConstraintsRemoved(),
 	})
+}
 
-	return err
+func (c *Counters) merge(other Counters) {
+	c.NodesCreated += other.NodesCreated
+	c.NodesDeleted += other.NodesDeleted
+	c.RelationshipsCreated += other.RelationshipsCreated
+	c.RelationshipsDeleted += other.RelationshipsDeleted
+	c.PropertiesSet += other.PropertiesSet
+	c.LabelsAdded += other.LabelsAdded
+	c.LabelsRemoved += other.LabelsRemoved
+	c.IndexesAdded += other.IndexesAdded
+	c.IndexesRemoved += other.IndexesRemoved
+	c.ConstraintsAdded += other.ConstraintsAdded
+	c.ConstraintsRemoved += other.ConstraintsRemoved
 }
 
-func (m *migrator) splitStatements(sql string) []string {
-	return strings.Split(sql, ";")
+func migrationStatements(migration Migration, direction Direction) ([]Statement, error) {
+	if direction == DirectionDown && migration.DownDerived {
+		up, err := splitStatements(migration.UpSQL, migration.UpLine)
+		if err != nil {
+			return nil, err
+		}
+		return deriveDownStatements(up)
+	}
+	if direction == DirectionDown {
+		return splitStatements(migration.DownSQL, migration.DownLine)
+	}
+	return splitStatements(migration.UpSQL, migration.UpLine)
+}
+
+func transactionBatches(statements []Statement, mode TransactionMode) [][]Statement {
+	if mode != TransactionPerStatement {
+		return groupStatements(statements)
+	}
+
+	batches := make([][]Statement, 0, len(statements))
+	for _, stmt := range statements {
+		batches = append(batches, []Statement{stmt})
+	}
+	return batches
+}
+
+func checkReversible(migrations []Migration) error {
+	for _, migration := range migrations {
+		if migration.Irreversible {
+			return &IrreversibleMigrationError{Version: migration.Version, Name: migration.Name}
+		}
+	}
+	return nil
+}
+
+func checkDirty(applied []MigrationRecord) error {
+	for _, record := range applied {
+		if record.Dirty {
+			return fmt.Errorf("%w: version %d failed at statement %d: %s", ErrDirtyMigration, record.Version, record.FailedStatement, record.Error)
+		}
+	}
+	return nil
+}
+
+func (m *migrator) findMigration(version int) (Migration, error) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, nil
+		}
+	}
+	return Migration{}, fmt.Errorf("%w: version %d", ErrMigrationNotFound, version)
+}
+
+func (m *migrator) verifyChecksums(applied []MigrationRecord) error {
+	if m.checksumPolicy == ChecksumIgnore {
+		return nil
+	}
+
+	var mismatched []int
+	for _, record := range applied {
+		if record.Dirty {
+			continue
+		}
+
+		migration, err := m.findMigration(record.Version)
+		if err != nil {
+			continue
+		}
+
+		if record.Checksum != migration.Checksum {
+			m.logger.Warn("checksum mismatch", "version", migration.Version, "name", migration.Name)
+			mismatched = append(mismatched, record.Version)
+		}
+	}
+
+	if len(mismatched) == 0 || m.checksumPolicy == ChecksumWarn {
+		return nil
+	}
+
+	return &ChecksumMismatchError{Versions: mismatched}
 }
@@ -3,10 +3,15 @@ package neo4go
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/fs"
+	"math"
+	"reflect"
 	"testing"
 	"testing/fstest"
 	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
 func TestMigratorUp(t *testing.T) {
@@ -79,7 +84,7 @@ func TestMigratorUp(t *testing.T) {
 						Version:   v,
 						Name:      "test",
 						AppliedAt: time.Now(),
-						Checksum:  "test",
+						Checksum:  checksumOf(tt.migrations, v),
 					})
 				}
 				return records, nil
@@ -264,7 +269,7 @@ func TestMigratorUpTo(t *testing.T) {
 						Version:   v,
 						Name:      "test",
 						AppliedAt: time.Now(),
-						Checksum:  "test",
+						Checksum:  checksumOf(tt.migrations, v),
 					})
 				}
 				return records, nil
@@ -351,7 +356,7 @@ func TestMigratorDownTo(t *testing.T) {
 						Version:   v,
 						Name:      "test",
 						AppliedAt: time.Now(),
-						Checksum:  "test",
+						Checksum:  checksumOf(tt.migrations, v),
 					})
 				}
 				return records, nil
@@ -381,6 +386,568 @@ func TestMigratorDownTo(t *testing.T) {
 	}
 }
 
+func TestMigratorPlan(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "indexes", UpSQL: "CREATE INDEX i1;\nCREATE INDEX i2;", DownSQL: "DROP INDEX i2;\nDROP INDEX i1;", UpLine: 2, DownLine: 6, Checksum: "def"},
+		{Version: 3, Name: "seed", UpSQL: "CREATE (:User);", DownSQL: "MATCH (u:User) DELETE u;", TxMode: NoTransaction, Checksum: "ghi"},
+	}
+
+	tests := []struct {
+		name             string
+		applied          []int
+		direction        Direction
+		target           int
+		expectVersions   []int
+		expectStatements []int
+		expectErr        error
+	}{
+		{
+			name:             "up plans every pending migration",
+			applied:          []int{1},
+			direction:        DirectionUp,
+			target:           math.MaxInt,
+			expectVersions:   []int{2, 3},
+			expectStatements: []int{2, 1},
+		},
+		{
+			name:             "up stops at target",
+			direction:        DirectionUp,
+			target:           2,
+			expectVersions:   []int{1, 2},
+			expectStatements: []int{1, 2},
+		},
+		{
+			name:             "down plans in reverse order",
+			applied:          []int{1, 2, 3},
+			direction:        DirectionDown,
+			target:           1,
+			expectVersions:   []int{3, 2},
+			expectStatements: []int{1, 2},
+		},
+		{
+			name:      "negative target",
+			direction: DirectionUp,
+			target:    -1,
+			expectErr: ErrInvalidVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newMockStorage()
+
+			for _, v := range tt.applied {
+				storage.RecordMigration(ctx, migrations[v-1])
+			}
+
+			m := &migrator{
+				driver:     nil,
+				storage:    storage,
+				migrations: migrations,
+				database:   "neo4j",
+				logger:     newMockLogger(),
+			}
+
+			plan, err := m.Plan(ctx, tt.direction, tt.target)
+
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var versions, statements []int
+			for _, planned := range plan {
+				if planned.Direction != tt.direction {
+					t.Errorf("expected direction %s, got %s", tt.direction, planned.Direction)
+				}
+				versions = append(versions, planned.Version)
+				statements = append(statements, len(planned.Statements))
+			}
+
+			if !reflect.DeepEqual(versions, tt.expectVersions) {
+				t.Errorf("expected versions %v, got %v", tt.expectVersions, versions)
+			}
+
+			if !reflect.DeepEqual(statements, tt.expectStatements) {
+				t.Errorf("expected statement counts %v, got %v", tt.expectStatements, statements)
+			}
+
+			records, _ := storage.GetAppliedMigrations(ctx)
+			if len(records) != len(tt.applied) {
+				t.Errorf("expected plan not to change applied migrations, got %d records", len(records))
+			}
+		})
+	}
+}
+
+func TestMigratorResults(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "indexes", UpSQL: "CREATE INDEX i1;", DownSQL: "DROP INDEX i1;", Checksum: "def"},
+		{Version: 3, Name: "more", UpSQL: "CREATE INDEX i2;", DownSQL: "DROP INDEX i2;", Checksum: "ghi"},
+	}
+
+	recordErr := errors.New("record failed")
+
+	tests := []struct {
+		name            string
+		applied         []int
+		failRecord      int
+		run             func(ctx context.Context, m *migrator) ([]MigrationResult, error)
+		expectVersions  []int
+		expectDirection Direction
+		expectFailed    int
+	}{
+		{
+			name:            "up returns every applied migration",
+			run:             func(ctx context.Context, m *migrator) ([]MigrationResult, error) { return m.UpWithResults(ctx) },
+			expectVersions:  []int{1, 2, 3},
+			expectDirection: DirectionUp,
+		},
+		{
+			name:            "up to stops at target",
+			applied:         []int{1},
+			run:             func(ctx context.Context, m *migrator) ([]MigrationResult, error) { return m.UpToWithResults(ctx, 2) },
+			expectVersions:  []int{2},
+			expectDirection: DirectionUp,
+		},
+		{
+			name:            "down to returns rollbacks in order",
+			applied:         []int{1, 2, 3},
+			run:             func(ctx context.Context, m *migrator) ([]MigrationResult, error) { return m.DownToWithResults(ctx, 0) },
+			expectVersions:  []int{3, 2, 1},
+			expectDirection: DirectionDown,
+		},
+		{
+			name:            "reset rolls back every migration",
+			applied:         []int{1, 2, 3},
+			run:             func(ctx context.Context, m *migrator) ([]MigrationResult, error) { return m.Reset(ctx) },
+			expectVersions:  []int{3, 2, 1},
+			expectDirection: DirectionDown,
+		},
+		{
+			name:            "failed migration is the last result",
+			failRecord:      2,
+			run:             func(ctx context.Context, m *migrator) ([]MigrationResult, error) { return m.UpWithResults(ctx) },
+			expectVersions:  []int{1, 2},
+			expectDirection: DirectionUp,
+			expectFailed:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newMockStorage()
+
+			for _, v := range tt.applied {
+				storage.RecordMigration(ctx, migrations[v-1])
+			}
+
+			storage.RecordFunc = func(ctx context.Context, migration Migration) error {
+				if migration.Version == tt.failRecord {
+					return recordErr
+				}
+				storage.appliedMigrations[migration.Version] = MigrationRecord{
+					Version:  migration.Version,
+					Name:     migration.Name,
+					Checksum: migration.Checksum,
+				}
+				return nil
+			}
+
+			m := &migrator{
+				driver:     nil,
+				storage:    storage,
+				migrations: migrations,
+				database:   "neo4j",
+				logger:     newMockLogger(),
+			}
+
+			results, err := tt.run(ctx, m)
+
+			if tt.expectFailed != 0 {
+				if !errors.Is(err, recordErr) {
+					t.Fatalf("expected record error, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var versions []int
+			for _, result := range results {
+				versions = append(versions, result.Version)
+
+				if result.Direction != tt.expectDirection {
+					t.Errorf("version %d: expected direction %s, got %s", result.Version, tt.expectDirection, result.Direction)
+				}
+
+				if failed := result.Error != nil; failed != (result.Version == tt.expectFailed) {
+					t.Errorf("version %d: unexpected error %v", result.Version, result.Error)
+				}
+			}
+
+			if !reflect.DeepEqual(versions, tt.expectVersions) {
+				t.Errorf("expected versions %v, got %v", tt.expectVersions, versions)
+			}
+		})
+	}
+}
+
+func TestMigratorIrreversible(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "lowercase_emails", UpSQL: "MATCH (u:User) SET u.email = toLower(u.email);", Irreversible: true, Checksum: "def"},
+		{Version: 3, Name: "indexes", UpSQL: "CREATE INDEX i1;", DownSQL: "DROP INDEX i1;", Checksum: "ghi"},
+	}
+
+	tests := []struct {
+		name          string
+		applied       []int
+		run           func(ctx context.Context, m *migrator) ([]MigrationResult, error)
+		expectErr     bool
+		expectApplied []int
+	}{
+		{
+			name:          "down past an irreversible migration fails before running",
+			applied:       []int{1, 2, 3},
+			run:           func(ctx context.Context, m *migrator) ([]MigrationResult, error) { return m.DownToWithResults(ctx, 0) },
+			expectErr:     true,
+			expectApplied: []int{1, 2, 3},
+		},
+		{
+			name:          "down to the irreversible migration succeeds",
+			applied:       []int{1, 2, 3},
+			run:           func(ctx context.Context, m *migrator) ([]MigrationResult, error) { return m.DownToWithResults(ctx, 2) },
+			expectApplied: []int{1, 2},
+		},
+		{
+			name:          "down on the irreversible migration fails",
+			applied:       []int{1, 2},
+			run:           func(ctx context.Context, m *migrator) ([]MigrationResult, error) { return m.DownWithResults(ctx) },
+			expectErr:     true,
+			expectApplied: []int{1, 2},
+		},
+		{
+			name:          "redo of the irreversible migration fails",
+			applied:       []int{1, 2},
+			run:           func(ctx context.Context, m *migrator) ([]MigrationResult, error) { return m.Redo(ctx) },
+			expectErr:     true,
+			expectApplied: []int{1, 2},
+		},
+		{
+			name:          "reset fails",
+			applied:       []int{1, 2, 3},
+			run:           func(ctx context.Context, m *migrator) ([]MigrationResult, error) { return m.Reset(ctx) },
+			expectErr:     true,
+			expectApplied: []int{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newMockStorage()
+
+			for _, v := range tt.applied {
+				storage.RecordMigration(ctx, migrations[v-1])
+			}
+
+			m := &migrator{
+				driver:     nil,
+				storage:    storage,
+				migrations: migrations,
+				database:   "neo4j",
+				logger:     newMockLogger(),
+			}
+
+			results, err := tt.run(ctx, m)
+
+			if tt.expectErr {
+				var irreversible *IrreversibleMigrationError
+				if !errors.As(err, &irreversible) || irreversible.Version != 2 {
+					t.Fatalf("expected IrreversibleMigrationError for version 2, got %v", err)
+				}
+				if !errors.Is(err, ErrIrreversibleMigration) {
+					t.Errorf("expected error to match ErrIrreversibleMigration")
+				}
+				if len(results) != 0 {
+					t.Errorf("expected no migration to run, got %d results", len(results))
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			statuses, err := m.Status(ctx)
+			if err != nil {
+				t.Fatalf("unexpected status error: %v", err)
+			}
+
+			var applied []int
+			for _, status := range statuses {
+				if status.Applied {
+					applied = append(applied, status.Version)
+				}
+				if status.Reversible == (status.Version == 2) {
+					t.Errorf("version %d: unexpected reversible %v", status.Version, status.Reversible)
+				}
+			}
+
+			if !reflect.DeepEqual(applied, tt.expectApplied) {
+				t.Errorf("expected applied versions %v, got %v", tt.expectApplied, applied)
+			}
+		})
+	}
+}
+
+func TestMigratorRedo(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "indexes", UpSQL: "CREATE INDEX i1;", DownSQL: "DROP INDEX i1;", Checksum: "def"},
+	}
+
+	tests := []struct {
+		name             string
+		applied          []Migration
+		expectErr        error
+		expectDirections []Direction
+	}{
+		{
+			name:             "redo latest migration",
+			applied:          []Migration{migrations[0], migrations[1]},
+			expectDirections: []Direction{DirectionDown, DirectionUp},
+		},
+		{
+			name:             "edited latest migration is redone with its new checksum",
+			applied:          []Migration{migrations[0], {Version: 2, Name: "indexes", Checksum: "edited"}},
+			expectDirections: []Direction{DirectionDown, DirectionUp},
+		},
+		{
+			name:      "edited earlier migration is still rejected",
+			applied:   []Migration{{Version: 1, Name: "initial", Checksum: "edited"}, migrations[1]},
+			expectErr: ErrChecksumMismatch,
+		},
+		{
+			name: "nothing applied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newMockStorage()
+
+			for _, migration := range tt.applied {
+				storage.RecordMigration(ctx, migration)
+			}
+
+			m := &migrator{
+				driver:     nil,
+				storage:    storage,
+				migrations: migrations,
+				database:   "neo4j",
+				logger:     newMockLogger(),
+			}
+
+			results, err := m.Redo(ctx)
+
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var directions []Direction
+			for _, result := range results {
+				if result.Version != 2 {
+					t.Errorf("expected only version 2 to be redone, got %d", result.Version)
+				}
+				directions = append(directions, result.Direction)
+			}
+
+			if !reflect.DeepEqual(directions, tt.expectDirections) {
+				t.Errorf("expected directions %v, got %v", tt.expectDirections, directions)
+			}
+
+			records, _ := storage.GetAppliedMigrations(ctx)
+			if len(records) != len(tt.applied) {
+				t.Fatalf("expected %d applied records, got %d", len(tt.applied), len(records))
+			}
+			if len(records) > 0 && records[len(records)-1].Checksum != checksumOf(migrations, records[len(records)-1].Version) {
+				t.Errorf("expected redone migration to store the current checksum, got %q", records[len(records)-1].Checksum)
+			}
+		})
+	}
+}
+
+func TestMigratorHooks(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "indexes", UpSQL: "CREATE INDEX i1;", DownSQL: "DROP INDEX i1;", Checksum: "def"},
+	}
+
+	policyErr := errors.New("deploy freeze")
+	recordErr := errors.New("record failed")
+
+	tests := []struct {
+		name         string
+		abortBefore  int
+		abortAfter   int
+		failRecord   int
+		dirty        bool
+		lockHeld     bool
+		expectErr    error
+		expectEvents []string
+	}{
+		{
+			name: "every hook in order",
+			expectEvents: []string{
+				"before all up 2",
+				"before each 1", "after each 1",
+				"before each 2", "after each 2",
+				"after all 2 <nil>",
+			},
+		},
+		{
+			name:        "before each aborts",
+			abortBefore: 2,
+			expectErr:   ErrHookAborted,
+			expectEvents: []string{
+				"before all up 2",
+				"before each 1", "after each 1",
+				"before each 2", "error 2",
+				"after all 1 error",
+			},
+		},
+		{
+			name:       "after each aborts",
+			abortAfter: 1,
+			expectErr:  ErrHookAborted,
+			expectEvents: []string{
+				"before all up 2",
+				"before each 1", "after each 1", "error 1",
+				"after all 1 error",
+			},
+		},
+		{
+			name:       "migration failure",
+			failRecord: 1,
+			expectErr:  recordErr,
+			expectEvents: []string{
+				"before all up 2",
+				"before each 1", "error 1",
+				"after all 1 error",
+			},
+		},
+		{
+			name:         "dirty state reaches after all",
+			dirty:        true,
+			expectErr:    ErrDirtyMigration,
+			expectEvents: []string{"after all 0 error"},
+		},
+		{
+			name:         "lock timeout reaches after all",
+			lockHeld:     true,
+			expectErr:    ErrLockTimeout,
+			expectEvents: []string{"after all 0 error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newMockStorage()
+
+			storage.RecordFunc = func(ctx context.Context, migration Migration) error {
+				if migration.Version == tt.failRecord {
+					return recordErr
+				}
+				storage.appliedMigrations[migration.Version] = MigrationRecord{Version: migration.Version, Checksum: migration.Checksum}
+				return nil
+			}
+
+			if tt.dirty {
+				storage.MarkDirty(ctx, migrations[0], 1, "boom")
+			}
+
+			if tt.lockHeld {
+				storage.AcquireLockFunc = func(ctx context.Context, owner string, lease time.Duration) (bool, error) {
+					return false, nil
+				}
+			}
+
+			var events []string
+			hooks := Hooks{
+				BeforeAll: func(ctx context.Context, direction Direction, migrations []Migration) error {
+					events = append(events, fmt.Sprintf("before all %s %d", direction, len(migrations)))
+					return nil
+				},
+				BeforeEach: func(ctx context.Context, migration Migration, direction Direction) error {
+					events = append(events, fmt.Sprintf("before each %d", migration.Version))
+					if migration.Version == tt.abortBefore {
+						return policyErr
+					}
+					return nil
+				},
+				AfterEach: func(ctx context.Context, migration Migration, result MigrationResult) error {
+					events = append(events, fmt.Sprintf("after each %d", result.Version))
+					if migration.Version == tt.abortAfter {
+						return policyErr
+					}
+					return nil
+				},
+				AfterAll: func(ctx context.Context, results []MigrationResult, err error) {
+					outcome := "<nil>"
+					if err != nil {
+						outcome = "error"
+					}
+					events = append(events, fmt.Sprintf("after all %d %s", len(results), outcome))
+				},
+				OnError: func(ctx context.Context, migration Migration, err error) {
+					events = append(events, fmt.Sprintf("error %d", migration.Version))
+				},
+			}
+
+			m := &migrator{
+				driver:      nil,
+				storage:     storage,
+				migrations:  migrations,
+				database:    "neo4j",
+				logger:      newMockLogger(),
+				hooks:       hooks,
+				lockTimeout: time.Nanosecond,
+			}
+
+			err := m.Up(ctx)
+
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(events, tt.expectEvents) {
+				t.Errorf("expected events %v, got %v", tt.expectEvents, events)
+			}
+		})
+	}
+}
+
 func TestMigratorStatus(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -435,7 +1002,7 @@ func TestMigratorStatus(t *testing.T) {
 						Version:   v,
 						Name:      "test",
 						AppliedAt: time.Now(),
-						Checksum:  "test",
+						Checksum:  checksumOf(tt.migrations, v),
 					})
 				}
 				return records, nil
@@ -612,3 +1179,670 @@ func TestNewMigrator(t *testing.T) {
 		})
 	}
 }
+
+func TestMigratorLock(t *testing.T) {
+	tests := []struct {
+		name          string
+		heldBy        string
+		heldFor       time.Duration
+		lockTimeout   time.Duration
+		acquireErr    error
+		expectErr     error
+		expectAnyErr  bool
+		expectAcquire bool
+	}{
+		{
+			name:          "acquires free lock",
+			expectAcquire: true,
+		},
+		{
+			name:          "takes over stale lock",
+			heldBy:        "other-replica",
+			heldFor:       -time.Minute,
+			expectAcquire: true,
+		},
+		{
+			name:        "times out while lock is held",
+			heldBy:      "other-replica",
+			heldFor:     time.Minute,
+			lockTimeout: time.Nanosecond,
+			expectErr:   ErrLockTimeout,
+		},
+		{
+			name:         "storage error while acquiring",
+			acquireErr:   errors.New("acquire failed"),
+			expectAnyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newMockStorage()
+			logger := newMockLogger()
+
+			storage.lockOwner = tt.heldBy
+			storage.lockExpiresAt = time.Now().Add(tt.heldFor)
+
+			if tt.acquireErr != nil {
+				storage.AcquireLockFunc = func(ctx context.Context, owner string, lease time.Duration) (bool, error) {
+					return false, tt.acquireErr
+				}
+			}
+
+			acquired := false
+			storage.RecordFunc = func(ctx context.Context, migration Migration) error {
+				acquired = storage.lockOwner == "replica-1"
+				return nil
+			}
+
+			m := &migrator{
+				driver:      nil,
+				storage:     storage,
+				migrations:  []Migration{{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;"}},
+				database:    "neo4j",
+				logger:      logger,
+				lockOwner:   "replica-1",
+				lockTimeout: tt.lockTimeout,
+			}
+
+			err := m.Up(ctx)
+
+			if tt.expectErr != nil || tt.expectAnyErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if tt.expectErr != nil && !errors.Is(err, tt.expectErr) {
+					t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if acquired != tt.expectAcquire {
+				t.Errorf("expected lock held during migration=%v, got %v", tt.expectAcquire, acquired)
+			}
+
+			if storage.lockOwner != "" {
+				t.Errorf("expected lock to be released, still held by %q", storage.lockOwner)
+			}
+		})
+	}
+}
+
+func TestMigratorLockHeartbeat(t *testing.T) {
+	tests := []struct {
+		name       string
+		refreshErr error
+	}{
+		{name: "lock taken over", refreshErr: ErrLockLost},
+		{name: "refresh keeps failing past the lease", refreshErr: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newMockStorage()
+			storage.RefreshLockFunc = func(ctx context.Context, owner string, lease time.Duration) error {
+				return tt.refreshErr
+			}
+
+			m := &migrator{
+				storage:   storage,
+				logger:    newMockLogger(),
+				lockOwner: "replica-1",
+			}
+
+			ctx, cancel := context.WithCancelCause(context.Background())
+			defer cancel(nil)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				m.heartbeat(ctx, cancel, 3*time.Millisecond)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("heartbeat did not cancel the run")
+			}
+
+			if !errors.Is(context.Cause(ctx), ErrLockLost) {
+				t.Fatalf("expected context to be cancelled with %v, got %v", ErrLockLost, context.Cause(ctx))
+			}
+		})
+	}
+}
+
+func TestTransactionBatches(t *testing.T) {
+	statements := []Statement{
+		{Text: "CREATE INDEX i1", Kind: StatementSchema},
+		{Text: "CREATE INDEX i2", Kind: StatementSchema},
+		{Text: "CREATE (:Node)", Kind: StatementData},
+	}
+
+	tests := []struct {
+		name string
+		mode TransactionMode
+		want [][]Statement
+	}{
+		{
+			name: "default groups schema and data statements",
+			mode: TransactionDefault,
+			want: [][]Statement{statements[:2], statements[2:]},
+		},
+		{
+			name: "transaction per statement",
+			mode: TransactionPerStatement,
+			want: [][]Statement{statements[:1], statements[1:2], statements[2:]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transactionBatches(statements, tt.mode); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected batches:\n%#v\ngot:\n%#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMigratorDirtyState(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "backfill", UpSQL: "MATCH (n) SET n.x = 1;", DownSQL: "MATCH (n) REMOVE n.x;", Checksum: "def", TxMode: NoTransaction},
+	}
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, m *migrator) error
+	}{
+		{name: "up", run: func(ctx context.Context, m *migrator) error { return m.Up(ctx) }},
+		{name: "up to", run: func(ctx context.Context, m *migrator) error { return m.UpTo(ctx, 2) }},
+		{name: "down", run: func(ctx context.Context, m *migrator) error { return m.Down(ctx) }},
+		{name: "down to", run: func(ctx context.Context, m *migrator) error { return m.DownTo(ctx, 0) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newMockStorage()
+
+			storage.RecordMigration(ctx, migrations[0])
+			storage.MarkDirty(ctx, migrations[1], 3, "syntax error")
+
+			m := &migrator{
+				driver:     nil,
+				storage:    storage,
+				migrations: migrations,
+				database:   "neo4j",
+				logger:     newMockLogger(),
+			}
+
+			err := tt.run(ctx, m)
+			if !errors.Is(err, ErrDirtyMigration) {
+				t.Fatalf("expected error %v, got %v", ErrDirtyMigration, err)
+			}
+
+			statuses, err := m.Status(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			dirty := statuses[1]
+			if !dirty.Dirty || dirty.Applied {
+				t.Errorf("expected version 2 to be dirty and not applied, got dirty=%v applied=%v", dirty.Dirty, dirty.Applied)
+			}
+
+			if dirty.FailedStatement != 3 || dirty.Error != "syntax error" {
+				t.Errorf("expected failure at statement 3 with message, got statement %d: %q", dirty.FailedStatement, dirty.Error)
+			}
+		})
+	}
+}
+
+func TestMigratorForce(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "backfill", UpSQL: "MATCH (n) SET n.x = 1;", DownSQL: "MATCH (n) REMOVE n.x;", Checksum: "def"},
+	}
+
+	tests := []struct {
+		name          string
+		version       int
+		applied       bool
+		expectErr     error
+		expectApplied []int
+	}{
+		{
+			name:          "force dirty migration as applied",
+			version:       2,
+			applied:       true,
+			expectApplied: []int{1, 2},
+		},
+		{
+			name:          "force dirty migration as not applied",
+			version:       2,
+			applied:       false,
+			expectApplied: []int{1},
+		},
+		{
+			name:      "unknown version",
+			version:   7,
+			applied:   true,
+			expectErr: ErrMigrationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newMockStorage()
+
+			storage.RecordMigration(ctx, migrations[0])
+			storage.MarkDirty(ctx, migrations[1], 1, "boom")
+
+			m := &migrator{
+				driver:     nil,
+				storage:    storage,
+				migrations: migrations,
+				database:   "neo4j",
+				logger:     newMockLogger(),
+			}
+
+			err := m.Force(ctx, tt.version, tt.applied)
+
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			records, _ := storage.GetAppliedMigrations(ctx)
+			var applied []int
+			for _, record := range records {
+				if record.Dirty {
+					t.Errorf("version %d should no longer be dirty", record.Version)
+				}
+				applied = append(applied, record.Version)
+			}
+
+			if !reflect.DeepEqual(applied, tt.expectApplied) {
+				t.Errorf("expected applied versions %v, got %v", tt.expectApplied, applied)
+			}
+		})
+	}
+}
+
+func TestMigratorRepair(t *testing.T) {
+	ctx := context.Background()
+	storage := newMockStorage()
+
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "indexes", UpSQL: "CREATE INDEX i1;", DownSQL: "DROP INDEX i1;", Checksum: "def"},
+		{Version: 3, Name: "more", UpSQL: "CREATE INDEX i2;", DownSQL: "DROP INDEX i2;", Checksum: "ghi"},
+	}
+
+	storage.RecordMigration(ctx, migrations[0])
+	storage.RecordMigration(ctx, Migration{Version: 2, Name: "indexes", Checksum: "edited"})
+	storage.MarkDirty(ctx, Migration{Version: 3, Name: "more", Checksum: "stale"}, 1, "boom")
+
+	m := &migrator{
+		driver:     nil,
+		storage:    storage,
+		migrations: migrations,
+		database:   "neo4j",
+		logger:     newMockLogger(),
+	}
+
+	repaired, err := m.Repair(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(repaired, []int{2}) {
+		t.Errorf("expected repaired versions [2], got %v", repaired)
+	}
+
+	records, _ := storage.GetAppliedMigrations(ctx)
+	if records[1].Checksum != "def" {
+		t.Errorf("expected version 2 checksum to be rewritten, got %q", records[1].Checksum)
+	}
+
+	if records[2].Checksum != "stale" {
+		t.Errorf("expected dirty version 3 checksum to be left alone, got %q", records[2].Checksum)
+	}
+}
+
+func TestMigratorBaseline(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "indexes", UpSQL: "CREATE INDEX i1;", DownSQL: "DROP INDEX i1;", Checksum: "def"},
+		{Version: 3, Name: "more", UpSQL: "CREATE INDEX i2;", DownSQL: "DROP INDEX i2;", Checksum: "ghi"},
+	}
+
+	tests := []struct {
+		name            string
+		version         int
+		applied         []int
+		dirty           bool
+		expectErr       error
+		expectBaselined []int
+		expectApplied   []int
+	}{
+		{
+			name:            "baseline fresh database",
+			version:         2,
+			expectBaselined: []int{1, 2},
+			expectApplied:   []int{1, 2},
+		},
+		{
+			name:            "skip already applied migrations",
+			version:         3,
+			applied:         []int{1},
+			expectBaselined: []int{2, 3},
+			expectApplied:   []int{1, 2, 3},
+		},
+		{
+			name:          "everything already applied",
+			version:       1,
+			applied:       []int{1},
+			expectApplied: []int{1},
+		},
+		{
+			name:      "unknown version",
+			version:   7,
+			expectErr: ErrMigrationNotFound,
+		},
+		{
+			name:      "dirty database",
+			version:   3,
+			dirty:     true,
+			expectErr: ErrDirtyMigration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newMockStorage()
+
+			for _, version := range tt.applied {
+				storage.RecordMigration(ctx, migrations[version-1])
+			}
+			if tt.dirty {
+				storage.MarkDirty(ctx, migrations[0], 1, "boom")
+			}
+
+			m := &migrator{
+				driver:     nil,
+				storage:    storage,
+				migrations: migrations,
+				database:   "neo4j",
+				logger:     newMockLogger(),
+			}
+
+			baselined, err := m.Baseline(ctx, tt.version)
+
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(baselined, tt.expectBaselined) {
+				t.Errorf("expected baselined versions %v, got %v", tt.expectBaselined, baselined)
+			}
+
+			statuses, err := m.Status(ctx)
+			if err != nil {
+				t.Fatalf("unexpected status error: %v", err)
+			}
+
+			var applied []int
+			for _, status := range statuses {
+				if !status.Applied {
+					continue
+				}
+				applied = append(applied, status.Version)
+
+				wantBaselined := false
+				for _, version := range tt.expectBaselined {
+					wantBaselined = wantBaselined || version == status.Version
+				}
+				if status.Baselined != wantBaselined {
+					t.Errorf("version %d: expected baselined=%v, got %v", status.Version, wantBaselined, status.Baselined)
+				}
+			}
+
+			if !reflect.DeepEqual(applied, tt.expectApplied) {
+				t.Errorf("expected applied versions %v, got %v", tt.expectApplied, applied)
+			}
+		})
+	}
+}
+
+func checksumOf(migrations []Migration, version int) string {
+	for _, migration := range migrations {
+		if migration.Version == version {
+			return migration.Checksum
+		}
+	}
+	return ""
+}
+
+func TestMigratorChecksumPolicy(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "indexes", UpSQL: "CREATE INDEX i1;", DownSQL: "DROP INDEX i1;", Checksum: "def"},
+		{Version: 3, Name: "more", UpSQL: "CREATE INDEX i2;", DownSQL: "DROP INDEX i2;", Checksum: "ghi"},
+	}
+
+	tests := []struct {
+		name         string
+		policy       ChecksumPolicy
+		run          func(ctx context.Context, m *migrator) error
+		expectErr    bool
+		expectWarned bool
+	}{
+		{
+			name:         "strict rejects up",
+			policy:       ChecksumStrict,
+			run:          func(ctx context.Context, m *migrator) error { return m.Up(ctx) },
+			expectErr:    true,
+			expectWarned: true,
+		},
+		{
+			name:         "strict rejects down to",
+			policy:       ChecksumStrict,
+			run:          func(ctx context.Context, m *migrator) error { return m.DownTo(ctx, 0) },
+			expectErr:    true,
+			expectWarned: true,
+		},
+		{
+			name:         "warn only logs",
+			policy:       ChecksumWarn,
+			run:          func(ctx context.Context, m *migrator) error { return m.Up(ctx) },
+			expectWarned: true,
+		},
+		{
+			name:   "ignore skips verification",
+			policy: ChecksumIgnore,
+			run:    func(ctx context.Context, m *migrator) error { return m.Up(ctx) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newMockStorage()
+			logger := newMockLogger()
+
+			storage.RecordMigration(ctx, Migration{Version: 1, Name: "initial", Checksum: "edited"})
+			storage.RecordMigration(ctx, Migration{Version: 2, Name: "indexes", Checksum: "edited"})
+
+			m := &migrator{
+				driver:         nil,
+				storage:        storage,
+				migrations:     migrations,
+				database:       "neo4j",
+				logger:         logger,
+				checksumPolicy: tt.policy,
+			}
+
+			err := tt.run(ctx, m)
+
+			if tt.expectErr {
+				var mismatch *ChecksumMismatchError
+				if !errors.As(err, &mismatch) || !errors.Is(err, ErrChecksumMismatch) {
+					t.Fatalf("expected checksum mismatch error, got %v", err)
+				}
+
+				if !reflect.DeepEqual(mismatch.Versions, []int{1, 2}) {
+					t.Errorf("expected mismatched versions [1 2], got %v", mismatch.Versions)
+				}
+
+				records, _ := storage.GetAppliedMigrations(ctx)
+				if len(records) != 2 {
+					t.Errorf("expected no migration to run, got %d records", len(records))
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if warned := len(logger.WarnLog) > 0; warned != tt.expectWarned {
+				t.Errorf("expected warning logged=%v, got %v", tt.expectWarned, warned)
+			}
+		})
+	}
+}
+
+func TestNewMigratorWithGoMigrations(t *testing.T) {
+	noop := func(ctx context.Context, tx neo4j.ManagedTransaction) error { return nil }
+
+	tests := []struct {
+		name           string
+		filesystem     fs.FS
+		register       func()
+		expectErr      error
+		expectVersions []int
+	}{
+		{
+			name: "merged with file migrations in version order",
+			filesystem: fstest.MapFS{
+				"001_initial.cypher": &fstest.MapFile{
+					Data: []byte("-- +neo4go Up\nCREATE CONSTRAINT c1;\n\n-- +neo4go Down\nDROP CONSTRAINT c1;"),
+				},
+				"003_indexes.cypher": &fstest.MapFile{
+					Data: []byte("-- +neo4go Up\nCREATE INDEX i1;\n\n-- +neo4go Down\nDROP INDEX i1;"),
+				},
+			},
+			register: func() {
+				AddMigration(2, "rekey_users", noop, noop)
+			},
+			expectVersions: []int{1, 2, 3},
+		},
+		{
+			name:       "only Go migrations",
+			filesystem: fstest.MapFS{},
+			register: func() {
+				AddMigrationNoTx(1, "backfill", nil, nil)
+			},
+			expectVersions: []int{1},
+		},
+		{
+			name: "version used by a file and a Go migration",
+			filesystem: fstest.MapFS{
+				"001_initial.cypher": &fstest.MapFile{
+					Data: []byte("-- +neo4go Up\nCREATE CONSTRAINT c1;\n\n-- +neo4go Down\nDROP CONSTRAINT c1;"),
+				},
+			},
+			register: func() {
+				AddMigration(1, "rekey_users", noop, noop)
+			},
+			expectErr: ErrDuplicateVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				goMigrationsMu.Lock()
+				defer goMigrationsMu.Unlock()
+				goMigrations = make(map[int]Migration)
+			})
+
+			tt.register()
+
+			m, err := newMigrator(nil, newMockStorage(), tt.filesystem, ".", "neo4j", newMockLogger())
+
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) {
+					t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var versions []int
+			for _, migration := range m.migrations {
+				versions = append(versions, migration.Version)
+			}
+
+			if !reflect.DeepEqual(versions, tt.expectVersions) {
+				t.Errorf("expected versions %v, got %v", tt.expectVersions, versions)
+			}
+
+			if err := m.Up(context.Background()); err != nil {
+				t.Fatalf("unexpected error applying migrations: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateConfigLock(t *testing.T) {
+	base := Config{URI: "bolt://localhost:7687", Username: "neo4j", Password: "password", MigrationsDir: "migrations"}
+
+	tests := []struct {
+		name        string
+		lease       time.Duration
+		timeout     time.Duration
+		expectError bool
+	}{
+		{name: "defaults", lease: 0, timeout: 0},
+		{name: "minimum lease", lease: time.Second},
+		{name: "lease too short", lease: 2 * time.Nanosecond, expectError: true},
+		{name: "negative lease", lease: -time.Second, expectError: true},
+		{name: "negative timeout", timeout: -time.Second, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			cfg.LockLease = tt.lease
+			cfg.LockTimeout = tt.timeout
+
+			err := validateConfig(cfg)
+			if tt.expectError != errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("expected invalid config=%v, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -4,9 +4,8 @@ import (
 	"bufio"
 	"crypto/sha256"
 	"fmt"
-	"io"
 	"io/fs"
-	"path/filepath"
+	"path"
 	"regexp"
 	"sort"
 	"strconv"
@@ -14,11 +13,22 @@ import (
 )
 
 const (
-	upMarker   = "-- +neo4go Up"
-	downMarker = "-- +neo4go Down"
+	markerPrefix = "-- +neo4go "
+	upMarker     = "-- +neo4go Up"
+	downMarker   = "-- +neo4go Down"
+
+	irreversibleMarker = "-- +neo4go Irreversible"
+
+	noTransactionAnnotation           = "NoTransaction"
+	transactionPerStatementAnnotation = "TransactionPerStatement"
+)
+
+var (
+	migrationFilePattern      = regexp.MustCompile(`^(\d+)_(.+)\.cypher$`)
+	splitMigrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.cypher$`)
 )
 
-var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.cypher$`)
+var namedSchemaCreatePattern = regexp.MustCompile(`(?i)^CREATE\s+(?:OR\s+REPLACE\s+)?(?:(?:RANGE|TEXT|POINT|LOOKUP|FULLTEXT|VECTOR|BTREE)\s+)?(INDEX|CONSTRAINT)\s+(` + "`(?:[^`]|``)+`" + `|[A-Za-z_][A-Za-z0-9_]*)`)
 
 type parser struct {
 	fs fs.FS
@@ -28,34 +38,96 @@ func newParser(filesystem fs.FS) *parser {
 	return &parser{fs: filesystem}
 }
 
-func (p *parser) parseMigrations(dir string) ([]Migration, error) {
-	entries, err := fs.ReadDir(p.fs, dir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
-	}
+type migrationFile struct {
+	version  int
+	name     string
+	path     string
+	downPath string
+	split    bool
+}
 
-	var migrations []Migration
-	for _, entry := range entries {
+func (p *parser) findMigrationFiles(dir string) ([]migrationFile, []string, error) {
+	var files []migrationFile
+	var ignored []string
+	pairs := make(map[string]int)
+
+	err := fs.WalkDir(p.fs, dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
 		if entry.IsDir() {
-			continue
+			return nil
 		}
 
-		matches := migrationFilePattern.FindStringSubmatch(entry.Name())
-		if matches == nil {
-			continue
+		if matches := splitMigrationFilePattern.FindStringSubmatch(entry.Name()); matches != nil {
+			version, err := strconv.Atoi(matches[1])
+			if err != nil {
+				ignored = append(ignored, filePath)
+				return nil
+			}
+
+			key := path.Join(path.Dir(filePath), matches[1]+"_"+matches[2])
+			i, exists := pairs[key]
+			if !exists {
+				i = len(files)
+				pairs[key] = i
+				files = append(files, migrationFile{version: version, name: matches[2], split: true})
+			}
+
+			if matches[3] == "up" {
+				files[i].path = filePath
+			} else {
+				files[i].downPath = filePath
+			}
+			return nil
 		}
 
-		version, err := strconv.Atoi(matches[1])
-		if err != nil {
-			continue
+		if matches := migrationFilePattern.FindStringSubmatch(entry.Name()); matches != nil {
+			version, err := strconv.Atoi(matches[1])
+			if err != nil {
+				ignored = append(ignored, filePath)
+				return nil
+			}
+
+			files = append(files, migrationFile{version: version, name: matches[2], path: filePath})
+			return nil
+		}
+
+		if strings.HasSuffix(entry.Name(), ".cypher") {
+			ignored = append(ignored, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	return files, ignored, nil
+}
+
+func (p *parser) parseMigrations(dir string) ([]Migration, error) {
+	files, _, err := p.findMigrationFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]string, len(files))
+	for _, file := range files {
+		if file.path == "" {
+			return nil, fmt.Errorf("%w: %s has no matching .up.cypher file", ErrInvalidMigrationFile, file.downPath)
 		}
 
-		name := matches[2]
-		filePath := filepath.Join(dir, entry.Name())
+		if existing, exists := seen[file.version]; exists {
+			return nil, &DuplicateVersionError{Version: file.version, First: existing, Second: file.path}
+		}
+		seen[file.version] = file.path
+	}
 
-		migration, err := p.parseMigrationFile(filePath, version, name)
+	var migrations []Migration
+	for _, file := range files {
+		migration, err := p.parseMigrationFile(file)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse migration %s: %w", entry.Name(), err)
+			return nil, fmt.Errorf("failed to parse migration %s: %w", file.path, err)
 		}
 
 		migrations = append(migrations, migration)
@@ -72,78 +144,276 @@ func (p *parser) parseMigrations(dir string) ([]Migration, error) {
 	return migrations, nil
 }
 
-func (p *parser) parseMigrationFile(filePath string, version int, name string) (Migration, error) {
-	file, err := p.fs.Open(filePath)
+func (p *parser) parseMigrationFile(file migrationFile) (Migration, error) {
+	migration, content, err := p.readMigration(file)
 	if err != nil {
-		return Migration{}, fmt.Errorf("failed to open file: %w", err)
+		return Migration{}, err
 	}
-	defer file.Close()
 
-	content, err := io.ReadAll(file)
-	if err != nil {
-		return Migration{}, fmt.Errorf("failed to read file: %w", err)
+	if _, err := migrationStatements(migration, DirectionUp); err != nil {
+		return Migration{}, err
 	}
 
-	upSQL, downSQL, err := p.splitUpDown(string(content))
-	if err != nil {
+	if _, err := migrationStatements(migration, DirectionDown); err != nil {
 		return Migration{}, err
 	}
 
-	checksum := calculateChecksum(content)
+	migration.Version = file.version
+	migration.Name = file.name
+	migration.Checksum = calculateChecksum(content)
+
+	return migration, nil
+}
+
+func (p *parser) readMigration(file migrationFile) (Migration, []byte, error) {
+	content, err := fs.ReadFile(p.fs, file.path)
+	if err != nil {
+		return Migration{}, nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if !file.split {
+		migration, err := p.splitUpDown(string(content))
+		return migration, content, err
+	}
+
+	s := sections{current: "up"}
+	if err := s.scan(string(content), true); err != nil {
+		return Migration{}, nil, err
+	}
+
+	if file.downPath != "" {
+		down, err := fs.ReadFile(p.fs, file.downPath)
+		if err != nil {
+			return Migration{}, nil, fmt.Errorf("failed to read file: %w", err)
+		}
+
+		s.current = "down"
+		s.hasDown = true
+		if err := s.scan(string(down), true); err != nil {
+			return Migration{}, nil, fmt.Errorf("%s: %w", file.downPath, err)
+		}
+
+		content = append(content, down...)
+	}
+
+	migration, err := s.migration()
+	return migration, content, err
+}
 
-	return Migration{
-		Version:  version,
-		Name:     name,
-		UpSQL:    upSQL,
-		DownSQL:  downSQL,
-		Checksum: checksum,
-	}, nil
+func (p *parser) splitUpDown(content string) (Migration, error) {
+	var s sections
+	if err := s.scan(content, false); err != nil {
+		return Migration{}, err
+	}
+	return s.migration()
 }
 
-func (p *parser) splitUpDown(content string) (string, string, error) {
+type sections struct {
+	up, down         strings.Builder
+	upLine, downLine int
+	current          string
+	hasDown          bool
+	irreversibleLine int
+	txMode           TransactionMode
+	txModeLine       int
+}
+
+func (s *sections) scan(content string, split bool) error {
 	scanner := bufio.NewScanner(strings.NewReader(content))
-	var upSQL, downSQL strings.Builder
-	var currentSection string
+	lineNumber := 0
 
 	for scanner.Scan() {
 		line := scanner.Text()
+		lineNumber++
+
+		if strings.HasPrefix(line, upMarker) || strings.HasPrefix(line, downMarker) {
+			if split {
+				return fmt.Errorf("%w: section marker at line %d is not allowed in a separate up or down file", ErrInvalidMigrationFile, lineNumber)
+			}
+
+			if strings.HasPrefix(line, upMarker) {
+				s.current = "up"
+				continue
+			}
 
-		if strings.HasPrefix(line, upMarker) {
-			currentSection = "up"
+			if s.irreversibleLine != 0 {
+				return fmt.Errorf("%w: Down section at line %d conflicts with the Irreversible marker at line %d", ErrInvalidMigrationFile, lineNumber, s.irreversibleLine)
+			}
+			s.current = "down"
+			s.hasDown = true
 			continue
 		}
 
-		if strings.HasPrefix(line, downMarker) {
-			currentSection = "down"
+		if strings.HasPrefix(line, irreversibleMarker) {
+			switch {
+			case s.current == "":
+				return fmt.Errorf("%w: Irreversible marker at line %d must follow the Up section", ErrInvalidMigrationFile, lineNumber)
+			case split && s.current == "up":
+				return fmt.Errorf("%w: Irreversible marker at line %d belongs in the down file", ErrInvalidMigrationFile, lineNumber)
+			case s.hasDown && (!split || s.downLine != 0):
+				return fmt.Errorf("%w: Irreversible marker at line %d conflicts with the Down section", ErrInvalidMigrationFile, lineNumber)
+			}
+			s.current = "irreversible"
+			s.irreversibleLine = lineNumber
+			continue
+		}
+
+		if strings.HasPrefix(line, markerPrefix) {
+			annotation := strings.TrimSpace(strings.TrimPrefix(line, markerPrefix))
+
+			if s.current != "" && !(split && s.current == "up" && s.upLine == 0) {
+				return fmt.Errorf("%w: annotation %q at line %d must appear before the Up section", ErrInvalidMigrationFile, annotation, lineNumber)
+			}
+
+			var mode TransactionMode
+			switch annotation {
+			case noTransactionAnnotation:
+				mode = NoTransaction
+			case transactionPerStatementAnnotation:
+				mode = TransactionPerStatement
+			default:
+				return fmt.Errorf("%w: unknown annotation %q at line %d", ErrInvalidMigrationFile, annotation, lineNumber)
+			}
+
+			if s.txModeLine != 0 && mode != s.txMode {
+				return fmt.Errorf("%w: annotation %q at line %d conflicts with %q at line %d", ErrInvalidMigrationFile, annotation, lineNumber, s.txMode, s.txModeLine)
+			}
+
+			s.txMode = mode
+			s.txModeLine = lineNumber
 			continue
 		}
 
-		switch currentSection {
+		switch s.current {
 		case "up":
-			upSQL.WriteString(line)
-			upSQL.WriteString("\n")
+			if s.upLine == 0 && strings.TrimSpace(line) != "" {
+				s.upLine = lineNumber
+			}
+			s.up.WriteString(line)
+			s.up.WriteString("\n")
 		case "down":
-			downSQL.WriteString(line)
-			downSQL.WriteString("\n")
+			if s.downLine == 0 && strings.TrimSpace(line) != "" {
+				s.downLine = lineNumber
+			}
+			s.down.WriteString(line)
+			s.down.WriteString("\n")
+		case "irreversible":
+			if trimmed := strings.TrimSpace(line); trimmed != "" && !isDashComment(trimmed) && !strings.HasPrefix(trimmed, "//") {
+				return fmt.Errorf("%w: statement at line %d follows the Irreversible marker", ErrInvalidMigrationFile, lineNumber)
+			}
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return "", "", fmt.Errorf("failed to scan file: %w", err)
+		return fmt.Errorf("failed to scan file: %w", err)
 	}
 
-	upStr := strings.TrimSpace(upSQL.String())
-	downStr := strings.TrimSpace(downSQL.String())
+	return nil
+}
+
+func (s *sections) migration() (Migration, error) {
+	upStr := strings.TrimSpace(s.up.String())
+	downStr := strings.TrimSpace(s.down.String())
 
 	if upStr == "" {
-		return "", "", ErrNoUpStatement
+		return Migration{}, ErrNoUpStatement
+	}
+
+	migration := Migration{
+		UpSQL:        upStr,
+		DownSQL:      downStr,
+		UpLine:       s.upLine,
+		DownLine:     s.downLine,
+		TxMode:       s.txMode,
+		Irreversible: s.irreversibleLine != 0,
+	}
+
+	if downStr != "" || migration.Irreversible {
+		return migration, nil
+	}
+
+	if s.hasDown {
+		return Migration{}, ErrNoDownStatement
+	}
+
+	up, err := splitStatements(upStr, s.upLine)
+	if err != nil {
+		return Migration{}, err
+	}
+
+	down, err := deriveDownStatements(up)
+	if err != nil {
+		return Migration{}, err
+	}
+
+	migration.DownSQL = joinStatements(down)
+	migration.DownDerived = true
+
+	return migration, nil
+}
+
+func DeriveDown(content string) (string, error) {
+	migration, err := (&parser{}).splitUpDown(content)
+	if err != nil {
+		return "", err
+	}
+
+	statements, err := splitStatements(migration.UpSQL, migration.UpLine)
+	if err != nil {
+		return "", err
+	}
+
+	down, err := deriveDownStatements(statements)
+	if err != nil {
+		return "", err
 	}
 
-	if downStr == "" {
-		return "", "", ErrNoDownStatement
+	return joinStatements(down), nil
+}
+
+func deriveDownStatements(up []Statement) ([]Statement, error) {
+	if len(up) == 0 {
+		return nil, ErrNoUpStatement
+	}
+
+	down := make([]Statement, 0, len(up))
+	for i := len(up) - 1; i >= 0; i-- {
+		stmt := up[i]
+
+		matches := namedSchemaCreatePattern.FindStringSubmatch(stmt.Text)
+		if matches == nil || isSchemaKeyword(matches[2]) {
+			return nil, fmt.Errorf("%w: statement at line %d is not a named CREATE INDEX or CREATE CONSTRAINT, so the Down section cannot be derived", ErrNoDownStatement, stmt.Line)
+		}
+
+		down = append(down, Statement{
+			Text: fmt.Sprintf("DROP %s %s IF EXISTS", strings.ToUpper(matches[1]), matches[2]),
+			Line: stmt.Line,
+			Kind: StatementSchema,
+		})
 	}
 
-	return upStr, downStr, nil
+	return down, nil
+}
+
+func isSchemaKeyword(name string) bool {
+	switch strings.ToUpper(name) {
+	case "IF", "FOR", "ON":
+		return true
+	default:
+		return false
+	}
+}
+
+func joinStatements(statements []Statement) string {
+	var b strings.Builder
+	for i, stmt := range statements {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(stmt.Text)
+		b.WriteString(";")
+	}
+	return b.String()
 }
 
 func calculateChecksum(content []byte) string {
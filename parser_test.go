@@ -3,6 +3,8 @@ package neo4go
 import (
 	"errors"
 	"io/fs"
+	"reflect"
+	"slices"
 	"testing"
 	"testing/fstest"
 )
@@ -66,11 +68,32 @@ DROP CONSTRAINT user_id IF EXISTS;`,
 			name: "missing down statement",
 			files: map[string]string{
 				"001_initial.cypher": `-- +neo4go Up
-CREATE CONSTRAINT user_id IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE;`,
+MATCH (u:User) SET u.active = true;`,
 			},
 			wantCount: 0,
 			wantErr:   ErrNoDownStatement,
 		},
+		{
+			name: "derived down statement",
+			files: map[string]string{
+				"001_initial.cypher": `-- +neo4go Up
+CREATE CONSTRAINT user_id IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE;`,
+			},
+			wantCount: 1,
+			wantErr:   nil,
+		},
+		{
+			name: "unterminated string literal",
+			files: map[string]string{
+				"001_initial.cypher": `-- +neo4go Up
+MATCH (u:User) SET u.status = 'active;
+
+-- +neo4go Down
+MATCH (u:User) REMOVE u.status;`,
+			},
+			wantCount: 0,
+			wantErr:   ErrInvalidMigrationFile,
+		},
 	}
 
 	for _, tt := range tests {
@@ -119,11 +142,16 @@ CREATE CONSTRAINT user_id IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE;`,
 
 func TestParserSplitUpDown(t *testing.T) {
 	tests := []struct {
-		name        string
-		content     string
-		wantUpSQL   string
-		wantDownSQL string
-		wantErr     error
+		name         string
+		content      string
+		wantUpSQL    string
+		wantDownSQL  string
+		wantUpLine   int
+		wantDownLine int
+		wantTxMode   TransactionMode
+		wantDerived  bool
+		wantIrrev    bool
+		wantErr      error
 	}{
 		{
 			name: "valid migration",
@@ -149,16 +177,157 @@ DROP INDEX user_email IF EXISTS;`,
 			wantDownSQL: "DROP CONSTRAINT user_id IF EXISTS;\nDROP INDEX user_email IF EXISTS;",
 			wantErr:     nil,
 		},
+		{
+			name: "section lines skip leading blank lines",
+			content: `-- +neo4go Up
+
+CREATE INDEX i1 IF NOT EXISTS FOR (n:Node) ON (n.id);
+
+-- +neo4go Down
+
+
+DROP INDEX i1 IF EXISTS;`,
+			wantUpSQL:    "CREATE INDEX i1 IF NOT EXISTS FOR (n:Node) ON (n.id);",
+			wantDownSQL:  "DROP INDEX i1 IF EXISTS;",
+			wantUpLine:   3,
+			wantDownLine: 8,
+		},
+		{
+			name: "no transaction annotation",
+			content: `-- +neo4go NoTransaction
+-- +neo4go Up
+CALL { MATCH (u:User) SET u.active = true } IN TRANSACTIONS OF 1000 ROWS;
+
+-- +neo4go Down
+CALL { MATCH (u:User) REMOVE u.active } IN TRANSACTIONS OF 1000 ROWS;`,
+			wantUpSQL:   "CALL { MATCH (u:User) SET u.active = true } IN TRANSACTIONS OF 1000 ROWS;",
+			wantDownSQL: "CALL { MATCH (u:User) REMOVE u.active } IN TRANSACTIONS OF 1000 ROWS;",
+			wantUpLine:  3,
+			wantTxMode:  NoTransaction,
+		},
+		{
+			name: "transaction per statement annotation",
+			content: `-- +neo4go TransactionPerStatement
+-- +neo4go Up
+CREATE INDEX i1 IF NOT EXISTS FOR (n:Node) ON (n.id);
+
+-- +neo4go Down
+DROP INDEX i1 IF EXISTS;`,
+			wantUpSQL:   "CREATE INDEX i1 IF NOT EXISTS FOR (n:Node) ON (n.id);",
+			wantDownSQL: "DROP INDEX i1 IF EXISTS;",
+			wantTxMode:  TransactionPerStatement,
+		},
+		{
+			name: "derived down section",
+			content: `-- +neo4go Up
+CREATE CONSTRAINT user_id IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE;
+CREATE OR REPLACE TEXT INDEX ` + "`user email`" + ` FOR (u:User) ON (u.email);
+CREATE FULLTEXT INDEX names FOR (n:User|Company) ON EACH [n.name];`,
+			wantUpSQL:   "CREATE CONSTRAINT user_id IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE;\nCREATE OR REPLACE TEXT INDEX `user email` FOR (u:User) ON (u.email);\nCREATE FULLTEXT INDEX names FOR (n:User|Company) ON EACH [n.name];",
+			wantDownSQL: "DROP INDEX names IF EXISTS;\nDROP INDEX `user email` IF EXISTS;\nDROP CONSTRAINT user_id IF EXISTS;",
+			wantDerived: true,
+		},
+		{
+			name: "unnamed schema statement cannot derive down",
+			content: `-- +neo4go Up
+CREATE CONSTRAINT user_id IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE;
+CREATE INDEX IF NOT EXISTS FOR (u:User) ON (u.email);`,
+			wantErr: ErrNoDownStatement,
+		},
+		{
+			name: "data statement cannot derive down",
+			content: `-- +neo4go Up
+CREATE INDEX i1 IF NOT EXISTS FOR (n:Node) ON (n.id);
+MATCH (n:Node) SET n.id = randomUUID();`,
+			wantErr: ErrNoDownStatement,
+		},
+		{
+			name: "empty down section is not derived",
+			content: `-- +neo4go Up
+CREATE INDEX i1 IF NOT EXISTS FOR (n:Node) ON (n.id);
+
+-- +neo4go Down
+`,
+			wantErr: ErrNoDownStatement,
+		},
+		{
+			name: "irreversible marker replaces down section",
+			content: `-- +neo4go Up
+MATCH (u:User) SET u.email = toLower(u.email);
+
+-- +neo4go Irreversible
+-- The original casing is lost.`,
+			wantUpSQL: "MATCH (u:User) SET u.email = toLower(u.email);",
+			wantIrrev: true,
+		},
+		{
+			name: "irreversible marker with down section",
+			content: `-- +neo4go Up
+MATCH (u:User) SET u.email = toLower(u.email);
+
+-- +neo4go Irreversible
+
+-- +neo4go Down
+MATCH (u:User) RETURN u;`,
+			wantErr: ErrInvalidMigrationFile,
+		},
+		{
+			name: "statement after irreversible marker",
+			content: `-- +neo4go Up
+MATCH (u:User) SET u.email = toLower(u.email);
+
+-- +neo4go Irreversible
+MATCH (u:User) RETURN u;`,
+			wantErr: ErrInvalidMigrationFile,
+		},
+		{
+			name: "irreversible marker before up section",
+			content: `-- +neo4go Irreversible
+-- +neo4go Up
+MATCH (u:User) SET u.email = toLower(u.email);`,
+			wantErr: ErrInvalidMigrationFile,
+		},
+		{
+			name: "unknown annotation",
+			content: `-- +neo4go Autocommit
+-- +neo4go Up
+CREATE INDEX i1 IF NOT EXISTS FOR (n:Node) ON (n.id);
+
+-- +neo4go Down
+DROP INDEX i1 IF EXISTS;`,
+			wantErr: ErrInvalidMigrationFile,
+		},
+		{
+			name: "conflicting annotations",
+			content: `-- +neo4go NoTransaction
+-- +neo4go TransactionPerStatement
+-- +neo4go Up
+CREATE INDEX i1 IF NOT EXISTS FOR (n:Node) ON (n.id);
+
+-- +neo4go Down
+DROP INDEX i1 IF EXISTS;`,
+			wantErr: ErrInvalidMigrationFile,
+		},
+		{
+			name: "annotation inside a section",
+			content: `-- +neo4go Up
+-- +neo4go NoTransaction
+CREATE INDEX i1 IF NOT EXISTS FOR (n:Node) ON (n.id);
+
+-- +neo4go Down
+DROP INDEX i1 IF EXISTS;`,
+			wantErr: ErrInvalidMigrationFile,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &parser{}
-			upSQL, downSQL, err := p.splitUpDown(tt.content)
+			migration, err := p.splitUpDown(tt.content)
 
 			if tt.wantErr != nil {
-				if err == nil {
-					t.Fatalf("expected error %v, got nil", tt.wantErr)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
 				}
 				return
 			}
@@ -167,12 +336,32 @@ DROP INDEX user_email IF EXISTS;`,
 				t.Fatalf("unexpected error: %v", err)
 			}
 
-			if upSQL != tt.wantUpSQL {
-				t.Errorf("expected up SQL:\n%s\ngot:\n%s", tt.wantUpSQL, upSQL)
+			if migration.DownDerived != tt.wantDerived {
+				t.Errorf("expected derived down %v, got %v", tt.wantDerived, migration.DownDerived)
 			}
 
-			if downSQL != tt.wantDownSQL {
-				t.Errorf("expected down SQL:\n%s\ngot:\n%s", tt.wantDownSQL, downSQL)
+			if migration.Irreversible != tt.wantIrrev {
+				t.Errorf("expected irreversible %v, got %v", tt.wantIrrev, migration.Irreversible)
+			}
+
+			if migration.TxMode != tt.wantTxMode {
+				t.Errorf("expected transaction mode %s, got %s", tt.wantTxMode, migration.TxMode)
+			}
+
+			if migration.UpSQL != tt.wantUpSQL {
+				t.Errorf("expected up SQL:\n%s\ngot:\n%s", tt.wantUpSQL, migration.UpSQL)
+			}
+
+			if migration.DownSQL != tt.wantDownSQL {
+				t.Errorf("expected down SQL:\n%s\ngot:\n%s", tt.wantDownSQL, migration.DownSQL)
+			}
+
+			if tt.wantUpLine > 0 && migration.UpLine != tt.wantUpLine {
+				t.Errorf("expected up line %d, got %d", tt.wantUpLine, migration.UpLine)
+			}
+
+			if tt.wantDownLine > 0 && migration.DownLine != tt.wantDownLine {
+				t.Errorf("expected down line %d, got %d", tt.wantDownLine, migration.DownLine)
 			}
 		})
 	}
@@ -213,6 +402,201 @@ func TestParserMigrationOrdering(t *testing.T) {
 	}
 }
 
+func TestParserSplitAndNestedMigrations(t *testing.T) {
+	tests := []struct {
+		name             string
+		files            map[string]string
+		wantErr          error
+		wantVersions     []int
+		wantDownSQL      string
+		wantDownLine     int
+		wantIrreversible bool
+	}{
+		{
+			name: "split pair",
+			files: map[string]string{
+				"001_users.up.cypher":   "-- +neo4go NoTransaction\nCREATE INDEX users IF NOT EXISTS FOR (u:User) ON (u.id);\n",
+				"001_users.down.cypher": "// rollback\nDROP INDEX users IF EXISTS;\n",
+			},
+			wantVersions: []int{1},
+			wantDownSQL:  "// rollback\nDROP INDEX users IF EXISTS;",
+			wantDownLine: 1,
+		},
+		{
+			name: "split up without down file derives down",
+			files: map[string]string{
+				"001_users.up.cypher": "CREATE INDEX users IF NOT EXISTS FOR (u:User) ON (u.id);\n",
+			},
+			wantVersions: []int{1},
+			wantDownSQL:  "DROP INDEX users IF EXISTS;",
+		},
+		{
+			name: "split up without down file and data statement",
+			files: map[string]string{
+				"001_users.up.cypher": "MATCH (u:User) SET u.active = true;\n",
+			},
+			wantErr: ErrNoDownStatement,
+		},
+		{
+			name: "irreversible down file",
+			files: map[string]string{
+				"001_users.up.cypher":   "MATCH (u:User) DELETE u;\n",
+				"001_users.down.cypher": "-- +neo4go Irreversible\n// deleted users cannot be restored\n",
+			},
+			wantVersions:     []int{1},
+			wantIrreversible: true,
+		},
+		{
+			name: "down file without up file",
+			files: map[string]string{
+				"001_users.down.cypher": "DROP INDEX users IF EXISTS;\n",
+			},
+			wantErr: ErrInvalidMigrationFile,
+		},
+		{
+			name: "section marker in split file",
+			files: map[string]string{
+				"001_users.up.cypher":   "-- +neo4go Up\nCREATE INDEX users IF NOT EXISTS FOR (u:User) ON (u.id);\n",
+				"001_users.down.cypher": "DROP INDEX users IF EXISTS;\n",
+			},
+			wantErr: ErrInvalidMigrationFile,
+		},
+		{
+			name: "down file without up file in nested directory",
+			files: map[string]string{
+				"2026/q2/004_orders.cypher":           "-- +neo4go Up\nCREATE INDEX orders IF NOT EXISTS FOR (o:Order) ON (o.id);\n",
+				"2026/q1/002_users.up.cypher":         "CREATE INDEX users IF NOT EXISTS FOR (u:User) ON (u.id);\n",
+				"2026/q1/002_users.down.cypher":       "DROP INDEX users IF EXISTS;\n",
+				"billing/003_invoices.cypher":         "-- +neo4go Up\nCREATE INDEX invoices IF NOT EXISTS FOR (i:Invoice) ON (i.id);\n",
+				"001_init.cypher":                     "-- +neo4go Up\nCREATE INDEX init IF NOT EXISTS FOR (n:Init) ON (n.id);\n",
+				"billing/notes/readme.txt":            "not a migration",
+				"billing/notes/005_draft.down.cypher": "DROP INDEX drafts IF EXISTS;\n",
+			},
+			wantErr: ErrInvalidMigrationFile,
+		},
+		{
+			name: "nested directories",
+			files: map[string]string{
+				"2026/q2/004_orders.cypher":     "-- +neo4go Up\nCREATE INDEX orders IF NOT EXISTS FOR (o:Order) ON (o.id);\n",
+				"2026/q1/002_users.up.cypher":   "CREATE INDEX users IF NOT EXISTS FOR (u:User) ON (u.id);\n",
+				"2026/q1/002_users.down.cypher": "DROP INDEX users IF EXISTS;\n",
+				"billing/003_invoices.cypher":   "-- +neo4go Up\nCREATE INDEX invoices IF NOT EXISTS FOR (i:Invoice) ON (i.id);\n",
+				"001_init.cypher":               "-- +neo4go Up\nCREATE INDEX init IF NOT EXISTS FOR (n:Init) ON (n.id);\n",
+				"billing/notes/readme.txt":      "not a migration",
+			},
+			wantVersions: []int{1, 2, 3, 4},
+			wantDownSQL:  "DROP INDEX init IF EXISTS;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem := fstest.MapFS{}
+			for name, content := range tt.files {
+				filesystem[name] = &fstest.MapFile{
+					Data: []byte(content),
+					Mode: fs.FileMode(0644),
+				}
+			}
+
+			p := newParser(filesystem)
+			migrations, err := p.parseMigrations(".")
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var versions []int
+			for _, migration := range migrations {
+				versions = append(versions, migration.Version)
+			}
+			if !slices.Equal(versions, tt.wantVersions) {
+				t.Fatalf("expected versions %v, got %v", tt.wantVersions, versions)
+			}
+
+			if migrations[0].DownSQL != tt.wantDownSQL {
+				t.Errorf("expected down SQL %q, got %q", tt.wantDownSQL, migrations[0].DownSQL)
+			}
+			if migrations[0].DownLine != tt.wantDownLine {
+				t.Errorf("expected down line %d, got %d", tt.wantDownLine, migrations[0].DownLine)
+			}
+			if migrations[0].Irreversible != tt.wantIrreversible {
+				t.Errorf("expected irreversible %v, got %v", tt.wantIrreversible, migrations[0].Irreversible)
+			}
+		})
+	}
+}
+
+func TestParserDuplicateVersions(t *testing.T) {
+	const combined = "-- +neo4go Up\nCREATE INDEX i1 IF NOT EXISTS FOR (n:A) ON (n.x);\n"
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  DuplicateVersionError
+	}{
+		{
+			name: "same version",
+			files: map[string]string{
+				"003_a.cypher": combined,
+				"003_b.cypher": combined,
+			},
+			want: DuplicateVersionError{Version: 3, First: "003_a.cypher", Second: "003_b.cypher"},
+		},
+		{
+			name: "different zero padding",
+			files: map[string]string{
+				"3_x.cypher":   combined,
+				"003_y.cypher": combined,
+			},
+			want: DuplicateVersionError{Version: 3, First: "003_y.cypher", Second: "3_x.cypher"},
+		},
+		{
+			name: "split pair and combined file in another directory",
+			files: map[string]string{
+				"004_b.up.cypher":   "CREATE INDEX i1 IF NOT EXISTS FOR (n:A) ON (n.x);\n",
+				"004_b.down.cypher": "DROP INDEX i1 IF EXISTS;\n",
+				"2026/004_a.cypher": combined,
+			},
+			want: DuplicateVersionError{Version: 4, First: "004_b.up.cypher", Second: "2026/004_a.cypher"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem := fstest.MapFS{}
+			for name, content := range tt.files {
+				filesystem[name] = &fstest.MapFile{
+					Data: []byte(content),
+					Mode: fs.FileMode(0644),
+				}
+			}
+
+			p := newParser(filesystem)
+			_, err := p.parseMigrations(".")
+
+			if !errors.Is(err, ErrDuplicateVersion) {
+				t.Fatalf("expected error %v, got %v", ErrDuplicateVersion, err)
+			}
+
+			var dupErr *DuplicateVersionError
+			if !errors.As(err, &dupErr) {
+				t.Fatalf("expected *DuplicateVersionError, got %T", err)
+			}
+			if *dupErr != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, *dupErr)
+			}
+		})
+	}
+}
+
 func TestParserInvalidFilenames(t *testing.T) {
 	filesystem := fstest.MapFS{
 		"invalid.cypher": &fstest.MapFile{
@@ -244,3 +628,48 @@ func TestParserInvalidFilenames(t *testing.T) {
 		t.Errorf("expected version 1, got %d", migrations[0].Version)
 	}
 }
+
+func TestDerivedDownStatements(t *testing.T) {
+	migration, err := (&parser{}).splitUpDown(`-- +neo4go Up
+
+CREATE CONSTRAINT user_id IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE;
+
+CREATE INDEX user_email IF NOT EXISTS FOR (u:User) ON (u.email);`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	statements, err := migrationStatements(migration, DirectionDown)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect := []Statement{
+		{Text: "DROP INDEX user_email IF EXISTS", Line: 5, Kind: StatementSchema},
+		{Text: "DROP CONSTRAINT user_id IF EXISTS", Line: 3, Kind: StatementSchema},
+	}
+	if !reflect.DeepEqual(statements, expect) {
+		t.Errorf("expected %+v, got %+v", expect, statements)
+	}
+}
+
+func TestDeriveDown(t *testing.T) {
+	down, err := DeriveDown(`-- +neo4go Up
+CREATE CONSTRAINT user_id IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE;
+CREATE INDEX user_email IF NOT EXISTS FOR (u:User) ON (u.email);
+
+-- +neo4go Down
+DROP CONSTRAINT user_id;`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect := "DROP INDEX user_email IF EXISTS;\nDROP CONSTRAINT user_id IF EXISTS;"
+	if down != expect {
+		t.Errorf("expected:\n%s\ngot:\n%s", expect, down)
+	}
+
+	if _, err := DeriveDown("-- +neo4go Up\nMATCH (n) SET n.x = 1;\n"); !errors.Is(err, ErrNoDownStatement) {
+		t.Errorf("expected ErrNoDownStatement for a data statement, got %v", err)
+	}
+}
@@ -1,14 +1,30 @@
 package neo4go
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
 
 type Migrator interface {
 	Up(ctx context.Context) error
 	Down(ctx context.Context) error
 	UpTo(ctx context.Context, version int) error
 	DownTo(ctx context.Context, version int) error
+	UpWithResults(ctx context.Context) ([]MigrationResult, error)
+	DownWithResults(ctx context.Context) ([]MigrationResult, error)
+	UpToWithResults(ctx context.Context, version int) ([]MigrationResult, error)
+	DownToWithResults(ctx context.Context, version int) ([]MigrationResult, error)
+	Redo(ctx context.Context) ([]MigrationResult, error)
+	Reset(ctx context.Context) ([]MigrationResult, error)
+	Plan(ctx context.Context, direction Direction, version int) ([]PlannedMigration, error)
 	Status(ctx context.Context) ([]MigrationStatus, error)
 	Version(ctx context.Context) (int, error)
+	Force(ctx context.Context, version int, applied bool) error
+	Repair(ctx context.Context) ([]int, error)
+	Baseline(ctx context.Context, version int) ([]int, error)
+	SchemaSnapshot(ctx context.Context) (SchemaSnapshot, error)
 	Close() error
 }
 
@@ -16,8 +32,16 @@ type Storage interface {
 	Init(ctx context.Context) error
 	GetAppliedMigrations(ctx context.Context) ([]MigrationRecord, error)
 	RecordMigration(ctx context.Context, migration Migration) error
+	RecordMigrationTx(ctx context.Context, tx neo4j.ManagedTransaction, migration Migration) error
+	RecordBaseline(ctx context.Context, migration Migration) error
 	RemoveMigration(ctx context.Context, version int) error
+	RemoveMigrationTx(ctx context.Context, tx neo4j.ManagedTransaction, version int) error
+	MarkDirty(ctx context.Context, migration Migration, failedStatement int, message string) error
+	UpdateChecksum(ctx context.Context, version int, checksum string) error
 	GetCurrentVersion(ctx context.Context) (int, error)
+	AcquireLock(ctx context.Context, owner string, lease time.Duration) (bool, error)
+	RefreshLock(ctx context.Context, owner string, lease time.Duration) error
+	ReleaseLock(ctx context.Context, owner string) error
 	Close() error
 }
 
@@ -27,3 +51,9 @@ type Logger interface {
 	Warn(msg string, args ...any)
 	Error(msg string, args ...any)
 }
+
+type Metrics interface {
+	ObserveMigration(database string, result MigrationResult)
+	ObserveStatement(database string, kind StatementKind, duration time.Duration)
+	SetSchemaState(database string, version, pending int)
+}
@@ -0,0 +1,90 @@
+package neo4go
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type GoMigrationFunc func(ctx context.Context, tx neo4j.ManagedTransaction) error
+
+type GoMigrationNoTxFunc func(ctx context.Context, session neo4j.SessionWithContext) error
+
+type goMigration struct {
+	up       GoMigrationFunc
+	down     GoMigrationFunc
+	upNoTx   GoMigrationNoTxFunc
+	downNoTx GoMigrationNoTxFunc
+}
+
+var (
+	goMigrationsMu sync.Mutex
+	goMigrations   = make(map[int]Migration)
+)
+
+func AddMigration(version int, name string, up, down GoMigrationFunc) {
+	register(Migration{
+		Version: version,
+		Name:    name,
+		goFuncs: &goMigration{up: up, down: down},
+	})
+}
+
+func AddMigrationNoTx(version int, name string, up, down GoMigrationNoTxFunc) {
+	register(Migration{
+		Version: version,
+		Name:    name,
+		TxMode:  NoTransaction,
+		goFuncs: &goMigration{upNoTx: up, downNoTx: down},
+	})
+}
+
+func register(migration Migration) {
+	goMigrationsMu.Lock()
+	defer goMigrationsMu.Unlock()
+
+	if migration.Version <= 0 {
+		panic(fmt.Sprintf("neo4go: invalid Go migration version %d", migration.Version))
+	}
+
+	if existing, exists := goMigrations[migration.Version]; exists {
+		panic(fmt.Sprintf("neo4go: Go migration version %d registered twice (%s and %s)", migration.Version, existing.Name, migration.Name))
+	}
+
+	goMigrations[migration.Version] = migration
+}
+
+func registeredGoMigrations() []Migration {
+	goMigrationsMu.Lock()
+	defer goMigrationsMu.Unlock()
+
+	migrations := make([]Migration, 0, len(goMigrations))
+	for _, migration := range goMigrations {
+		migrations = append(migrations, migration)
+	}
+	return migrations
+}
+
+func mergeMigrations(fileMigrations, goMigrations []Migration) ([]Migration, error) {
+	versions := make(map[int]Migration, len(fileMigrations))
+	for _, migration := range fileMigrations {
+		versions[migration.Version] = migration
+	}
+
+	merged := append([]Migration(nil), fileMigrations...)
+	for _, migration := range goMigrations {
+		if existing, exists := versions[migration.Version]; exists {
+			return nil, fmt.Errorf("%w: version %d is used by file migration %q and Go migration %q", ErrDuplicateVersion, migration.Version, existing.Name, migration.Name)
+		}
+		merged = append(merged, migration)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Version < merged[j].Version
+	})
+
+	return merged, nil
+}
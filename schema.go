@@ -0,0 +1,189 @@
+package neo4go
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+var internalConstraints = map[string]bool{
+	"schema_migration_version": true,
+	"schema_migration_lock_id": true,
+}
+
+func (d SchemaDiff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Unexpected) == 0 && len(d.Changed) == 0
+}
+
+func (m *migrator) SchemaSnapshot(ctx context.Context) (snapshot SchemaSnapshot, err error) {
+	ctx, span := m.startSpan(ctx, "neo4go.schema_snapshot")
+	defer func() { endSpan(span, err) }()
+
+	session := m.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:   neo4j.AccessModeRead,
+		DatabaseName: m.database,
+	})
+	defer session.Close(ctx)
+
+	constraints, err := readSchemaObjects(ctx, session, `
+		SHOW CONSTRAINTS
+		YIELD name, type, entityType, labelsOrTypes, properties, createStatement
+		RETURN name, type, entityType, labelsOrTypes, properties, createStatement
+		ORDER BY name
+	`)
+	if err != nil {
+		return SchemaSnapshot{}, fmt.Errorf("failed to read constraints: %w", err)
+	}
+
+	indexes, err := readSchemaObjects(ctx, session, `
+		SHOW INDEXES
+		YIELD name, type, entityType, labelsOrTypes, properties, owningConstraint, createStatement
+		WHERE owningConstraint IS NULL
+		RETURN name, type, entityType, labelsOrTypes, properties, createStatement
+		ORDER BY name
+	`)
+	if err != nil {
+		return SchemaSnapshot{}, fmt.Errorf("failed to read indexes: %w", err)
+	}
+
+	for _, constraint := range constraints {
+		if !internalConstraints[constraint.Name] {
+			snapshot.Constraints = append(snapshot.Constraints, constraint)
+		}
+	}
+	snapshot.Indexes = indexes
+
+	return snapshot.normalize(), nil
+}
+
+func readSchemaObjects(ctx context.Context, session neo4j.SessionWithContext, query string) ([]SchemaObject, error) {
+	records, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+		return result.Collect(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
+	}
+
+	var objects []SchemaObject
+	for _, record := range records.([]*neo4j.Record) {
+		values := record.AsMap()
+		objects = append(objects, SchemaObject{
+			Name:            stringValue(values["name"]),
+			Type:            stringValue(values["type"]),
+			EntityType:      stringValue(values["entityType"]),
+			LabelsOrTypes:   stringValues(values["labelsOrTypes"]),
+			Properties:      stringValues(values["properties"]),
+			CreateStatement: stringValue(values["createStatement"]),
+		})
+	}
+
+	return objects, nil
+}
+
+func stringValue(value any) string {
+	s, _ := value.(string)
+	return s
+}
+
+func stringValues(value any) []string {
+	items, _ := value.([]any)
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, stringValue(item))
+	}
+	return values
+}
+
+func (s SchemaSnapshot) normalize() SchemaSnapshot {
+	for _, objects := range [][]SchemaObject{s.Constraints, s.Indexes} {
+		for i := range objects {
+			if objects[i].LabelsOrTypes == nil {
+				objects[i].LabelsOrTypes = []string{}
+			}
+			if objects[i].Properties == nil {
+				objects[i].Properties = []string{}
+			}
+		}
+		sort.Slice(objects, func(i, j int) bool {
+			return objects[i].Name < objects[j].Name
+		})
+	}
+
+	if s.Constraints == nil {
+		s.Constraints = []SchemaObject{}
+	}
+	if s.Indexes == nil {
+		s.Indexes = []SchemaObject{}
+	}
+
+	return s
+}
+
+func (s SchemaSnapshot) Cypher() string {
+	var b strings.Builder
+	b.WriteString("// Generated by neo4go. Do not edit by hand.\n")
+
+	for _, objects := range [][]SchemaObject{s.Constraints, s.Indexes} {
+		if len(objects) == 0 {
+			continue
+		}
+
+		b.WriteString("\n")
+		for _, object := range objects {
+			b.WriteString(object.CreateStatement)
+			b.WriteString(";\n")
+		}
+	}
+
+	return b.String()
+}
+
+func DiffSchema(expected, actual SchemaSnapshot) SchemaDiff {
+	var diff SchemaDiff
+	diffSchemaObjects(&diff, SchemaObjectConstraint, expected.Constraints, actual.Constraints)
+	diffSchemaObjects(&diff, SchemaObjectIndex, expected.Indexes, actual.Indexes)
+	return diff
+}
+
+func diffSchemaObjects(diff *SchemaDiff, kind SchemaObjectKind, expected, actual []SchemaObject) {
+	actualByName := make(map[string]SchemaObject, len(actual))
+	for _, object := range actual {
+		actualByName[object.Name] = object
+	}
+
+	expectedByName := make(map[string]bool, len(expected))
+	for _, want := range expected {
+		expectedByName[want.Name] = true
+
+		got, exists := actualByName[want.Name]
+		if !exists {
+			diff.Missing = append(diff.Missing, SchemaChange{Kind: kind, Name: want.Name, Expected: &want})
+			continue
+		}
+
+		if !sameDefinition(want, got) {
+			diff.Changed = append(diff.Changed, SchemaChange{Kind: kind, Name: want.Name, Expected: &want, Actual: &got})
+		}
+	}
+
+	for _, got := range actual {
+		if !expectedByName[got.Name] {
+			diff.Unexpected = append(diff.Unexpected, SchemaChange{Kind: kind, Name: got.Name, Actual: &got})
+		}
+	}
+}
+
+func sameDefinition(a, b SchemaObject) bool {
+	return a.Type == b.Type &&
+		a.EntityType == b.EntityType &&
+		slices.Equal(a.LabelsOrTypes, b.LabelsOrTypes) &&
+		slices.Equal(a.Properties, b.Properties)
+}
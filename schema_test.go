@@ -0,0 +1,97 @@
+package neo4go
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSchema(t *testing.T) {
+	userID := SchemaObject{
+		Name: "user_id_unique", Type: "UNIQUENESS", EntityType: "NODE",
+		LabelsOrTypes: []string{"User"}, Properties: []string{"id"},
+		CreateStatement: "CREATE CONSTRAINT `user_id_unique` FOR (n:`User`) REQUIRE (n.`id`) IS UNIQUE",
+	}
+	userEmail := SchemaObject{
+		Name: "user_email_idx", Type: "RANGE", EntityType: "NODE",
+		LabelsOrTypes: []string{"User"}, Properties: []string{"email"},
+		CreateStatement: "CREATE RANGE INDEX `user_email_idx` FOR (n:`User`) ON (n.`email`)",
+	}
+	userEmailText := userEmail
+	userEmailText.Type = "TEXT"
+	handMade := SchemaObject{
+		Name: "hand_made", Type: "RANGE", EntityType: "NODE",
+		LabelsOrTypes: []string{"Post"}, Properties: []string{"slug"},
+	}
+
+	tests := []struct {
+		name     string
+		expected SchemaSnapshot
+		actual   SchemaSnapshot
+		expect   SchemaDiff
+	}{
+		{
+			name:     "no drift",
+			expected: SchemaSnapshot{Constraints: []SchemaObject{userID}, Indexes: []SchemaObject{userEmail}},
+			actual:   SchemaSnapshot{Constraints: []SchemaObject{userID}, Indexes: []SchemaObject{userEmail}},
+		},
+		{
+			name:     "index created by hand",
+			expected: SchemaSnapshot{Indexes: []SchemaObject{userEmail}},
+			actual:   SchemaSnapshot{Indexes: []SchemaObject{userEmail, handMade}},
+			expect: SchemaDiff{
+				Unexpected: []SchemaChange{{Kind: SchemaObjectIndex, Name: "hand_made", Actual: &handMade}},
+			},
+		},
+		{
+			name:     "constraint dropped by hand",
+			expected: SchemaSnapshot{Constraints: []SchemaObject{userID}},
+			actual:   SchemaSnapshot{},
+			expect: SchemaDiff{
+				Missing: []SchemaChange{{Kind: SchemaObjectConstraint, Name: "user_id_unique", Expected: &userID}},
+			},
+		},
+		{
+			name:     "index recreated with another type",
+			expected: SchemaSnapshot{Indexes: []SchemaObject{userEmail}},
+			actual:   SchemaSnapshot{Indexes: []SchemaObject{userEmailText}},
+			expect: SchemaDiff{
+				Changed: []SchemaChange{{Kind: SchemaObjectIndex, Name: "user_email_idx", Expected: &userEmail, Actual: &userEmailText}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffSchema(tt.expected, tt.actual)
+
+			if !reflect.DeepEqual(diff, tt.expect) {
+				t.Errorf("expected diff %+v, got %+v", tt.expect, diff)
+			}
+
+			if diff.Empty() != tt.expect.Empty() {
+				t.Errorf("expected Empty() = %v", tt.expect.Empty())
+			}
+		})
+	}
+}
+
+func TestSchemaSnapshotCypher(t *testing.T) {
+	snapshot := SchemaSnapshot{
+		Constraints: []SchemaObject{{Name: "c1", CreateStatement: "CREATE CONSTRAINT `c1` FOR (n:`A`) REQUIRE (n.`id`) IS UNIQUE"}},
+		Indexes: []SchemaObject{
+			{Name: "i2", CreateStatement: "CREATE RANGE INDEX `i2` FOR (n:`A`) ON (n.`y`)"},
+			{Name: "i1", CreateStatement: "CREATE RANGE INDEX `i1` FOR (n:`A`) ON (n.`x`)"},
+		},
+	}.normalize()
+
+	expect := "// Generated by neo4go. Do not edit by hand.\n" +
+		"\n" +
+		"CREATE CONSTRAINT `c1` FOR (n:`A`) REQUIRE (n.`id`) IS UNIQUE;\n" +
+		"\n" +
+		"CREATE RANGE INDEX `i1` FOR (n:`A`) ON (n.`x`);\n" +
+		"CREATE RANGE INDEX `i2` FOR (n:`A`) ON (n.`y`);\n"
+
+	if got := snapshot.Cypher(); got != expect {
+		t.Errorf("expected:\n%s\ngot:\n%s", expect, got)
+	}
+}
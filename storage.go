@@ -29,15 +29,28 @@ func (s *neo4jStorage) Init(ctx context.Context) error {
 	})
 	defer session.Close(ctx)
 
-	query := `
+	queries := []string{
+		`
 		CREATE CONSTRAINT schema_migration_version IF NOT EXISTS
 		FOR (m:SchemaMigration)
 		REQUIRE m.version IS UNIQUE
-	`
+		`,
+		`
+		CREATE CONSTRAINT schema_migration_lock_id IF NOT EXISTS
+		FOR (l:SchemaMigrationLock)
+		REQUIRE l.id IS UNIQUE
+		`,
+	}
 
-	_, err := session.Run(ctx, query, nil)
-	if err != nil {
-		return fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
+	for _, query := range queries {
+		result, err := session.Run(ctx, query, nil)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
+		}
+
+		if _, err := result.Consume(ctx); err != nil {
+			return fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
+		}
 	}
 
 	s.logger.Info("initialized schema migration tracking")
@@ -53,7 +66,9 @@ func (s *neo4jStorage) GetAppliedMigrations(ctx context.Context) ([]MigrationRec
 
 	query := `
 		MATCH (m:SchemaMigration)
-		RETURN m.version AS version, m.name AS name, m.applied_at AS applied_at, m.checksum AS checksum
+		RETURN m.version AS version, m.name AS name, m.applied_at AS applied_at, m.checksum AS checksum,
+			coalesce(m.baselined, false) AS baselined, coalesce(m.dirty, false) AS dirty, coalesce(m.error, '') AS error,
+			coalesce(m.failed_statement, 0) AS failed_statement
 		ORDER BY m.version
 	`
 
@@ -70,12 +85,20 @@ func (s *neo4jStorage) GetAppliedMigrations(ctx context.Context) ([]MigrationRec
 		name, _ := record.Get("name")
 		appliedAt, _ := record.Get("applied_at")
 		checksum, _ := record.Get("checksum")
+		baselined, _ := record.Get("baselined")
+		dirty, _ := record.Get("dirty")
+		errorMessage, _ := record.Get("error")
+		failedStatement, _ := record.Get("failed_statement")
 
 		records = append(records, MigrationRecord{
-			Version:   int(version.(int64)),
-			Name:      name.(string),
-			AppliedAt: appliedAt.(time.Time),
-			Checksum:  checksum.(string),
+			Version:         int(version.(int64)),
+			Name:            name.(string),
+			AppliedAt:       appliedAt.(time.Time),
+			Checksum:        checksum.(string),
+			Baselined:       baselined.(bool),
+			Dirty:           dirty.(bool),
+			Error:           errorMessage.(string),
+			FailedStatement: int(failedStatement.(int64)),
 		})
 	}
 
@@ -93,13 +116,19 @@ func (s *neo4jStorage) RecordMigration(ctx context.Context, migration Migration)
 	})
 	defer session.Close(ctx)
 
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return nil, s.RecordMigrationTx(ctx, tx, migration)
+	})
+	return err
+}
+
+func (s *neo4jStorage) RecordMigrationTx(ctx context.Context, tx neo4j.ManagedTransaction, migration Migration) error {
 	query := `
-		CREATE (m:SchemaMigration {
-			version: $version,
-			name: $name,
-			applied_at: datetime(),
-			checksum: $checksum
-		})
+		MERGE (m:SchemaMigration {version: $version})
+		SET m.name = $name,
+			m.applied_at = datetime(),
+			m.checksum = $checksum
+		REMOVE m.baselined, m.dirty, m.error, m.failed_statement, m.failed_at
 	`
 
 	params := map[string]any{
@@ -108,7 +137,10 @@ func (s *neo4jStorage) RecordMigration(ctx context.Context, migration Migration)
 		"checksum": migration.Checksum,
 	}
 
-	_, err := session.Run(ctx, query, params)
+	result, err := tx.Run(ctx, query, params)
+	if err == nil {
+		_, err = result.Consume(ctx)
+	}
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
 	}
@@ -117,6 +149,43 @@ func (s *neo4jStorage) RecordMigration(ctx context.Context, migration Migration)
 	return nil
 }
 
+func (s *neo4jStorage) RecordBaseline(ctx context.Context, migration Migration) error {
+	session := s.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:   neo4j.AccessModeWrite,
+		DatabaseName: s.database,
+	})
+	defer session.Close(ctx)
+
+	query := `
+		MERGE (m:SchemaMigration {version: $version})
+		SET m.name = $name,
+			m.applied_at = datetime(),
+			m.checksum = $checksum,
+			m.baselined = true
+		REMOVE m.dirty, m.error, m.failed_statement, m.failed_at
+	`
+
+	params := map[string]any{
+		"version":  migration.Version,
+		"name":     migration.Name,
+		"checksum": migration.Checksum,
+	}
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
+	}
+
+	s.logger.Info("recorded baseline migration", "version", migration.Version, "name", migration.Name)
+	return nil
+}
+
 func (s *neo4jStorage) RemoveMigration(ctx context.Context, version int) error {
 	session := s.driver.NewSession(ctx, neo4j.SessionConfig{
 		AccessMode:   neo4j.AccessModeWrite,
@@ -124,6 +193,13 @@ func (s *neo4jStorage) RemoveMigration(ctx context.Context, version int) error {
 	})
 	defer session.Close(ctx)
 
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return nil, s.RemoveMigrationTx(ctx, tx, version)
+	})
+	return err
+}
+
+func (s *neo4jStorage) RemoveMigrationTx(ctx context.Context, tx neo4j.ManagedTransaction, version int) error {
 	query := `
 		MATCH (m:SchemaMigration {version: $version})
 		DELETE m
@@ -133,7 +209,10 @@ func (s *neo4jStorage) RemoveMigration(ctx context.Context, version int) error {
 		"version": version,
 	}
 
-	_, err := session.Run(ctx, query, params)
+	result, err := tx.Run(ctx, query, params)
+	if err == nil {
+		_, err = result.Consume(ctx)
+	}
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
 	}
@@ -142,6 +221,79 @@ func (s *neo4jStorage) RemoveMigration(ctx context.Context, version int) error {
 	return nil
 }
 
+func (s *neo4jStorage) MarkDirty(ctx context.Context, migration Migration, failedStatement int, message string) error {
+	session := s.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:   neo4j.AccessModeWrite,
+		DatabaseName: s.database,
+	})
+	defer session.Close(ctx)
+
+	query := `
+		MERGE (m:SchemaMigration {version: $version})
+		ON CREATE SET m.name = $name,
+			m.applied_at = datetime(),
+			m.checksum = $checksum
+		SET m.dirty = true,
+			m.error = $error,
+			m.failed_statement = $failed_statement,
+			m.failed_at = datetime()
+	`
+
+	params := map[string]any{
+		"version":          migration.Version,
+		"name":             migration.Name,
+		"checksum":         migration.Checksum,
+		"error":            message,
+		"failed_statement": failedStatement,
+	}
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
+	}
+
+	s.logger.Warn("marked migration dirty", "version", migration.Version, "name", migration.Name, "failed_statement", failedStatement)
+	return nil
+}
+
+func (s *neo4jStorage) UpdateChecksum(ctx context.Context, version int, checksum string) error {
+	session := s.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:   neo4j.AccessModeWrite,
+		DatabaseName: s.database,
+	})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (m:SchemaMigration {version: $version})
+		SET m.checksum = $checksum
+	`
+
+	params := map[string]any{
+		"version":  version,
+		"checksum": checksum,
+	}
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
+	}
+
+	s.logger.Info("updated migration checksum", "version", version)
+	return nil
+}
+
 func (s *neo4jStorage) GetCurrentVersion(ctx context.Context) (int, error) {
 	session := s.driver.NewSession(ctx, neo4j.SessionConfig{
 		AccessMode:   neo4j.AccessModeRead,
@@ -151,6 +303,7 @@ func (s *neo4jStorage) GetCurrentVersion(ctx context.Context) (int, error) {
 
 	query := `
 		MATCH (m:SchemaMigration)
+		WHERE coalesce(m.dirty, false) = false
 		RETURN m.version AS version
 		ORDER BY m.version DESC
 		LIMIT 1
@@ -170,6 +323,133 @@ func (s *neo4jStorage) GetCurrentVersion(ctx context.Context) (int, error) {
 	return 0, nil
 }
 
+func (s *neo4jStorage) AcquireLock(ctx context.Context, owner string, lease time.Duration) (bool, error) {
+	session := s.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:   neo4j.AccessModeWrite,
+		DatabaseName: s.database,
+	})
+	defer session.Close(ctx)
+
+	// Touching the node first takes its write lock, so the ownership check
+	// below cannot interleave with a concurrent acquirer.
+	query := `
+		MERGE (l:SchemaMigrationLock {id: $id})
+		SET l.checked_at = datetime()
+		WITH l, l.owner AS previous
+		WHERE previous IS NULL OR previous = $owner OR l.expires_at < datetime()
+		SET l.owner = $owner,
+			l.acquired_at = datetime(),
+			l.expires_at = datetime() + duration({milliseconds: $lease_ms})
+		RETURN previous
+	`
+
+	params := map[string]any{
+		"id":       schemaMigrationLockID,
+		"owner":    owner,
+		"lease_ms": lease.Milliseconds(),
+	}
+
+	previous, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		if !result.Next(ctx) {
+			return nil, result.Err()
+		}
+
+		previous, _ := result.Record().Get("previous")
+		if previous == nil {
+			return owner, nil
+		}
+		return previous, nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
+	}
+
+	if previous == nil {
+		return false, nil
+	}
+
+	if previous != owner {
+		s.logger.Warn("took over stale migration lock", "owner", owner, "previous_owner", previous)
+	}
+
+	s.logger.Debug("acquired migration lock", "owner", owner)
+	return true, nil
+}
+
+func (s *neo4jStorage) RefreshLock(ctx context.Context, owner string, lease time.Duration) error {
+	session := s.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:   neo4j.AccessModeWrite,
+		DatabaseName: s.database,
+	})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (l:SchemaMigrationLock {id: $id, owner: $owner})
+		SET l.expires_at = datetime() + duration({milliseconds: $lease_ms})
+		RETURN l.owner AS owner
+	`
+
+	params := map[string]any{
+		"id":       schemaMigrationLockID,
+		"owner":    owner,
+		"lease_ms": lease.Milliseconds(),
+	}
+
+	held, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return false, err
+		}
+		return result.Next(ctx), result.Err()
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
+	}
+
+	if !held.(bool) {
+		return fmt.Errorf("%w: owner %s", ErrLockLost, owner)
+	}
+
+	return nil
+}
+
+func (s *neo4jStorage) ReleaseLock(ctx context.Context, owner string) error {
+	session := s.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:   neo4j.AccessModeWrite,
+		DatabaseName: s.database,
+	})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (l:SchemaMigrationLock {id: $id, owner: $owner})
+		DELETE l
+	`
+
+	params := map[string]any{
+		"id":    schemaMigrationLockID,
+		"owner": owner,
+	}
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseConnection, err)
+	}
+
+	s.logger.Debug("released migration lock", "owner", owner)
+	return nil
+}
+
 func (s *neo4jStorage) Close() error {
 	return s.driver.Close(context.Background())
 }
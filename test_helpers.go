@@ -2,19 +2,32 @@ package neo4go
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
 type mockStorage struct {
-	mu                sync.RWMutex
-	InitFunc          func(ctx context.Context) error
-	GetAppliedFunc    func(ctx context.Context) ([]MigrationRecord, error)
-	RecordFunc        func(ctx context.Context, migration Migration) error
-	RemoveFunc        func(ctx context.Context, version int) error
-	GetVersionFunc    func(ctx context.Context) (int, error)
-	CloseFunc         func() error
-	appliedMigrations map[int]MigrationRecord
+	mu                 sync.RWMutex
+	InitFunc           func(ctx context.Context) error
+	GetAppliedFunc     func(ctx context.Context) ([]MigrationRecord, error)
+	RecordFunc         func(ctx context.Context, migration Migration) error
+	RecordTxFunc       func(ctx context.Context, tx neo4j.ManagedTransaction, migration Migration) error
+	RecordBaselineFunc func(ctx context.Context, migration Migration) error
+	RemoveFunc         func(ctx context.Context, version int) error
+	RemoveTxFunc       func(ctx context.Context, tx neo4j.ManagedTransaction, version int) error
+	MarkDirtyFunc      func(ctx context.Context, migration Migration, failedStatement int, message string) error
+	UpdateChecksumFunc func(ctx context.Context, version int, checksum string) error
+	GetVersionFunc     func(ctx context.Context) (int, error)
+	AcquireLockFunc    func(ctx context.Context, owner string, lease time.Duration) (bool, error)
+	RefreshLockFunc    func(ctx context.Context, owner string, lease time.Duration) error
+	ReleaseLockFunc    func(ctx context.Context, owner string) error
+	CloseFunc          func() error
+	appliedMigrations  map[int]MigrationRecord
+	lockOwner          string
+	lockExpiresAt      time.Time
 }
 
 func newMockStorage() *mockStorage {
@@ -45,6 +58,10 @@ func (m *mockStorage) GetAppliedMigrations(ctx context.Context) ([]MigrationReco
 	for _, record := range m.appliedMigrations {
 		records = append(records, record)
 	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Version < records[j].Version
+	})
 	return records, nil
 }
 
@@ -65,6 +82,31 @@ func (m *mockStorage) RecordMigration(ctx context.Context, migration Migration)
 	return nil
 }
 
+func (m *mockStorage) RecordMigrationTx(ctx context.Context, tx neo4j.ManagedTransaction, migration Migration) error {
+	if m.RecordTxFunc != nil {
+		return m.RecordTxFunc(ctx, tx, migration)
+	}
+	return m.RecordMigration(ctx, migration)
+}
+
+func (m *mockStorage) RecordBaseline(ctx context.Context, migration Migration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.RecordBaselineFunc != nil {
+		return m.RecordBaselineFunc(ctx, migration)
+	}
+
+	m.appliedMigrations[migration.Version] = MigrationRecord{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		AppliedAt: time.Now(),
+		Checksum:  migration.Checksum,
+		Baselined: true,
+	}
+	return nil
+}
+
 func (m *mockStorage) RemoveMigration(ctx context.Context, version int) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -77,6 +119,53 @@ func (m *mockStorage) RemoveMigration(ctx context.Context, version int) error {
 	return nil
 }
 
+func (m *mockStorage) RemoveMigrationTx(ctx context.Context, tx neo4j.ManagedTransaction, version int) error {
+	if m.RemoveTxFunc != nil {
+		return m.RemoveTxFunc(ctx, tx, version)
+	}
+	return m.RemoveMigration(ctx, version)
+}
+
+func (m *mockStorage) MarkDirty(ctx context.Context, migration Migration, failedStatement int, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.MarkDirtyFunc != nil {
+		return m.MarkDirtyFunc(ctx, migration, failedStatement, message)
+	}
+
+	record, exists := m.appliedMigrations[migration.Version]
+	if !exists {
+		record = MigrationRecord{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Now(),
+			Checksum:  migration.Checksum,
+		}
+	}
+
+	record.Dirty = true
+	record.Error = message
+	record.FailedStatement = failedStatement
+	m.appliedMigrations[migration.Version] = record
+	return nil
+}
+
+func (m *mockStorage) UpdateChecksum(ctx context.Context, version int, checksum string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.UpdateChecksumFunc != nil {
+		return m.UpdateChecksumFunc(ctx, version, checksum)
+	}
+
+	if record, exists := m.appliedMigrations[version]; exists {
+		record.Checksum = checksum
+		m.appliedMigrations[version] = record
+	}
+	return nil
+}
+
 func (m *mockStorage) GetCurrentVersion(ctx context.Context) (int, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -86,14 +175,61 @@ func (m *mockStorage) GetCurrentVersion(ctx context.Context) (int, error) {
 	}
 
 	maxVersion := 0
-	for version := range m.appliedMigrations {
-		if version > maxVersion {
+	for version, record := range m.appliedMigrations {
+		if !record.Dirty && version > maxVersion {
 			maxVersion = version
 		}
 	}
 	return maxVersion, nil
 }
 
+func (m *mockStorage) AcquireLock(ctx context.Context, owner string, lease time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.AcquireLockFunc != nil {
+		return m.AcquireLockFunc(ctx, owner, lease)
+	}
+
+	if m.lockOwner != "" && m.lockOwner != owner && time.Now().Before(m.lockExpiresAt) {
+		return false, nil
+	}
+
+	m.lockOwner = owner
+	m.lockExpiresAt = time.Now().Add(lease)
+	return true, nil
+}
+
+func (m *mockStorage) RefreshLock(ctx context.Context, owner string, lease time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.RefreshLockFunc != nil {
+		return m.RefreshLockFunc(ctx, owner, lease)
+	}
+
+	if m.lockOwner != owner {
+		return ErrLockLost
+	}
+
+	m.lockExpiresAt = time.Now().Add(lease)
+	return nil
+}
+
+func (m *mockStorage) ReleaseLock(ctx context.Context, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ReleaseLockFunc != nil {
+		return m.ReleaseLockFunc(ctx, owner)
+	}
+
+	if m.lockOwner == owner {
+		m.lockOwner = ""
+	}
+	return nil
+}
+
 func (m *mockStorage) Close() error {
 	if m.CloseFunc != nil {
 		return m.CloseFunc()
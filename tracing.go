@@ -0,0 +1,67 @@
+package neo4go
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const tracerName = "go.kirha.ai/neo4go"
+
+const (
+	attrVersion       = attribute.Key("neo4go.migration.version")
+	attrName          = attribute.Key("neo4go.migration.name")
+	attrDirection     = attribute.Key("neo4go.migration.direction")
+	attrTxMode        = attribute.Key("neo4go.migration.transaction")
+	attrStatements    = attribute.Key("neo4go.migration.statements")
+	attrTargetVersion = attribute.Key("neo4go.target_version")
+	attrStatementLine = attribute.Key("neo4go.statement.line")
+	attrStatementKind = attribute.Key("neo4go.statement.kind")
+	attrDBSystem      = attribute.Key("db.system")
+	attrDBNamespace   = attribute.Key("db.namespace")
+	attrDBQueryText   = attribute.Key("db.query.text")
+)
+
+func (m *migrator) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	var tracer trace.Tracer = noop.Tracer{}
+	if m.tracer != nil {
+		tracer = m.tracer
+	}
+
+	attrs = append(attrs, attrDBSystem.String("neo4j"), attrDBNamespace.String(m.database))
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func (m *migrator) startMigrationSpan(ctx context.Context, migration Migration, direction Direction) (context.Context, trace.Span) {
+	return m.startSpan(ctx, "neo4go.migration",
+		attrVersion.Int(migration.Version),
+		attrName.String(migration.Name),
+		attrDirection.String(string(direction)),
+		attrTxMode.String(migration.TxMode.String()),
+	)
+}
+
+func (m *migrator) startStatementSpan(ctx context.Context, migration Migration, stmt Statement) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attrVersion.Int(migration.Version),
+		attrName.String(migration.Name),
+		attrStatementLine.Int(stmt.Line),
+		attrStatementKind.String(string(stmt.Kind)),
+	}
+	if !m.redactStatements {
+		attrs = append(attrs, attrDBQueryText.String(stmt.Text))
+	}
+
+	return m.startSpan(ctx, "neo4go.statement", attrs...)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
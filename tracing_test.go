@@ -0,0 +1,180 @@
+package neo4go
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTracedMigrator(storage *mockStorage, migrations []Migration, redact bool) (*migrator, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	m := &migrator{
+		driver:           nil,
+		storage:          storage,
+		migrations:       migrations,
+		database:         "neo4j",
+		logger:           newMockLogger(),
+		tracer:           provider.Tracer(tracerName),
+		redactStatements: redact,
+	}
+	return m, exporter
+}
+
+func spanAttr(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestMigratorTracing(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "initial", UpSQL: "CREATE CONSTRAINT c1;", DownSQL: "DROP CONSTRAINT c1;", Checksum: "abc"},
+		{Version: 2, Name: "indexes", UpSQL: "CREATE INDEX i1;", DownSQL: "DROP INDEX i1;", Checksum: "def"},
+	}
+
+	recordErr := errors.New("record failed")
+
+	tests := []struct {
+		name          string
+		failRecord    int
+		run           func(ctx context.Context, m *migrator) error
+		expectSpans   []string
+		expectVersion []int64
+		expectError   bool
+	}{
+		{
+			name:          "up",
+			run:           func(ctx context.Context, m *migrator) error { return m.Up(ctx) },
+			expectSpans:   []string{"neo4go.migration", "neo4go.migration", "neo4go.up"},
+			expectVersion: []int64{1, 2},
+		},
+		{
+			name:          "up to",
+			run:           func(ctx context.Context, m *migrator) error { return m.UpTo(ctx, 1) },
+			expectSpans:   []string{"neo4go.migration", "neo4go.up_to"},
+			expectVersion: []int64{1},
+		},
+		{
+			name:          "failed migration",
+			failRecord:    1,
+			run:           func(ctx context.Context, m *migrator) error { return m.Up(ctx) },
+			expectSpans:   []string{"neo4go.migration", "neo4go.up"},
+			expectVersion: []int64{1},
+			expectError:   true,
+		},
+		{
+			name:        "status",
+			run:         func(ctx context.Context, m *migrator) error { _, err := m.Status(ctx); return err },
+			expectSpans: []string{"neo4go.status"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newMockStorage()
+			storage.RecordFunc = func(ctx context.Context, migration Migration) error {
+				if migration.Version == tt.failRecord {
+					return recordErr
+				}
+				storage.appliedMigrations[migration.Version] = MigrationRecord{Version: migration.Version, Checksum: migration.Checksum}
+				return nil
+			}
+
+			m, exporter := newTracedMigrator(storage, migrations, false)
+
+			err := tt.run(context.Background(), m)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			spans := exporter.GetSpans()
+
+			var names []string
+			var versions []int64
+			for _, span := range spans {
+				names = append(names, span.Name)
+
+				if span.Name != "neo4go.migration" {
+					continue
+				}
+
+				version, _ := spanAttr(span, attrVersion)
+				versions = append(versions, version.AsInt64())
+
+				root := spans[len(spans)-1]
+				if span.Parent.SpanID() != root.SpanContext.SpanID() {
+					t.Errorf("migration span %d is not a child of %s", version.AsInt64(), root.Name)
+				}
+			}
+
+			if !reflect.DeepEqual(names, tt.expectSpans) {
+				t.Errorf("expected spans %v, got %v", tt.expectSpans, names)
+			}
+
+			if !reflect.DeepEqual(versions, tt.expectVersion) {
+				t.Errorf("expected migration spans for versions %v, got %v", tt.expectVersion, versions)
+			}
+
+			root := spans[len(spans)-1]
+			if namespace, _ := spanAttr(root, attrDBNamespace); namespace.AsString() != "neo4j" {
+				t.Errorf("expected db.namespace neo4j, got %q", namespace.AsString())
+			}
+
+			if failed := root.Status.Code == codes.Error; failed != tt.expectError {
+				t.Errorf("expected error status=%v, got %v", tt.expectError, root.Status)
+			}
+		})
+	}
+}
+
+func TestStatementSpanRedaction(t *testing.T) {
+	migration := Migration{Version: 3, Name: "users"}
+	stmt := Statement{Text: "CREATE (:User {password: 'secret'})", Line: 4, Kind: StatementData}
+
+	tests := []struct {
+		name       string
+		redact     bool
+		expectText bool
+	}{
+		{name: "statement text recorded", redact: false, expectText: true},
+		{name: "statement text redacted", redact: true, expectText: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, exporter := newTracedMigrator(newMockStorage(), nil, tt.redact)
+
+			_, span := m.startStatementSpan(context.Background(), migration, stmt)
+			endSpan(span, nil)
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("expected 1 span, got %d", len(spans))
+			}
+
+			text, ok := spanAttr(spans[0], attrDBQueryText)
+			if ok != tt.expectText {
+				t.Fatalf("expected statement text present=%v, got %v", tt.expectText, ok)
+			}
+
+			if ok && text.AsString() != stmt.Text {
+				t.Errorf("expected statement text %q, got %q", stmt.Text, text.AsString())
+			}
+
+			if line, _ := spanAttr(spans[0], attrStatementLine); line.AsInt64() != 4 {
+				t.Errorf("expected line 4, got %d", line.AsInt64())
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package neo4go
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+const (
+	RuleDuplicateVersion = "duplicate-version"
+	RuleFileName         = "file-name"
+	RuleEmptySection     = "empty-section"
+	RuleMixedStatements  = "mixed-statements"
+	RuleMissingIfExists  = "missing-if-exists"
+	RuleUnbalancedQuote  = "unbalanced-quote"
+	RuleInvalidFile      = "invalid-file"
+)
+
+type ValidationIssue struct {
+	File     string
+	Line     int
+	Severity Severity
+	Rule     string
+	Message  string
+}
+
+var (
+	ifNotExistsPattern   = regexp.MustCompile(`(?i)\bIF\s+NOT\s+EXISTS\b`)
+	ifExistsPattern      = regexp.MustCompile(`(?i)\bIF\s+EXISTS\b`)
+	createOrReplaceRegex = regexp.MustCompile(`(?i)^CREATE\s+OR\s+REPLACE\b`)
+)
+
+func Validate(filesystem fs.FS) ([]ValidationIssue, error) {
+	p := newParser(filesystem)
+
+	migrationFiles, ignored, err := p.findMigrationFiles(".")
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []ValidationIssue
+	for _, file := range ignored {
+		message := "file name does not match {version}_{name}.cypher and is ignored"
+		if matches := migrationFilePattern.FindStringSubmatch(path.Base(file)); matches != nil {
+			message = fmt.Sprintf("invalid version %q", matches[1])
+		}
+		issues = append(issues, ValidationIssue{
+			File:     file,
+			Severity: SeverityError,
+			Rule:     RuleFileName,
+			Message:  message,
+		})
+	}
+
+	files := make(map[int]string)
+	found := 0
+
+	for _, file := range migrationFiles {
+		if file.path == "" {
+			issues = append(issues, ValidationIssue{
+				File:     file.downPath,
+				Severity: SeverityError,
+				Rule:     RuleFileName,
+				Message:  "down file has no matching .up.cypher file",
+			})
+			continue
+		}
+
+		if file.version <= 0 {
+			issues = append(issues, ValidationIssue{
+				File:     file.path,
+				Severity: SeverityError,
+				Rule:     RuleFileName,
+				Message:  fmt.Sprintf("invalid version %d", file.version),
+			})
+			continue
+		}
+		found++
+
+		if existing, exists := files[file.version]; exists {
+			issues = append(issues, ValidationIssue{
+				File:     file.path,
+				Severity: SeverityError,
+				Rule:     RuleDuplicateVersion,
+				Message:  fmt.Sprintf("version %d is also used by %s", file.version, existing),
+			})
+		} else {
+			files[file.version] = file.path
+		}
+
+		issues = append(issues, p.validateFile(file)...)
+	}
+
+	for _, migration := range registeredGoMigrations() {
+		if existing, exists := files[migration.Version]; exists {
+			issues = append(issues, ValidationIssue{
+				File:     existing,
+				Severity: SeverityError,
+				Rule:     RuleDuplicateVersion,
+				Message:  fmt.Sprintf("version %d is also used by Go migration %q", migration.Version, migration.Name),
+			})
+		}
+	}
+
+	if found == 0 && len(issues) == 0 {
+		return nil, ErrNoMigrations
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		return issues[i].Line < issues[j].Line
+	})
+
+	return issues, nil
+}
+
+func (p *parser) validateFile(f migrationFile) []ValidationIssue {
+	file := f.path
+
+	migration, _, err := p.readMigration(f)
+	switch {
+	case errors.Is(err, ErrNoUpStatement):
+		return []ValidationIssue{{File: file, Severity: SeverityError, Rule: RuleEmptySection, Message: "Up section is missing or empty"}}
+	case errors.Is(err, ErrNoDownStatement):
+		message := "Down section is missing or empty"
+		if err != ErrNoDownStatement {
+			message = err.Error()
+		}
+		return []ValidationIssue{{File: file, Severity: SeverityError, Rule: RuleEmptySection, Message: message}}
+	case err != nil:
+		return []ValidationIssue{{File: file, Severity: SeverityError, Rule: RuleInvalidFile, Message: err.Error()}}
+	}
+
+	var issues []ValidationIssue
+	for _, direction := range []Direction{DirectionUp, DirectionDown} {
+		file := f.path
+		if direction == DirectionDown && f.downPath != "" {
+			file = f.downPath
+		}
+
+		statements, err := migrationStatements(migration, direction)
+		if err != nil {
+			rule := RuleInvalidFile
+			if errors.Is(err, errUnterminatedQuote) {
+				rule = RuleUnbalancedQuote
+			}
+			issues = append(issues, ValidationIssue{File: file, Severity: SeverityError, Rule: rule, Message: err.Error()})
+			continue
+		}
+
+		issues = append(issues, validateStatements(file, direction, migration.TxMode, statements)...)
+	}
+
+	return issues
+}
+
+func validateStatements(file string, direction Direction, txMode TransactionMode, statements []Statement) []ValidationIssue {
+	var issues []ValidationIssue
+
+	if groups := groupStatements(statements); txMode == TransactionDefault && len(groups) > 1 {
+		issues = append(issues, ValidationIssue{
+			File:     file,
+			Line:     groups[1][0].Line,
+			Severity: SeverityWarning,
+			Rule:     RuleMixedStatements,
+			Message:  fmt.Sprintf("%s section mixes schema and data statements, which run in separate transactions", direction),
+		})
+	}
+
+	for _, stmt := range statements {
+		if stmt.Kind != StatementSchema {
+			continue
+		}
+
+		upper := strings.ToUpper(stmt.Text)
+		switch {
+		case strings.HasPrefix(upper, "CREATE") && !createOrReplaceRegex.MatchString(stmt.Text) && !ifNotExistsPattern.MatchString(stmt.Text):
+			issues = append(issues, ValidationIssue{
+				File:     file,
+				Line:     stmt.Line,
+				Severity: SeverityWarning,
+				Rule:     RuleMissingIfExists,
+				Message:  "CREATE schema statement without IF NOT EXISTS",
+			})
+		case strings.HasPrefix(upper, "DROP") && !ifExistsPattern.MatchString(stmt.Text):
+			issues = append(issues, ValidationIssue{
+				File:     file,
+				Line:     stmt.Line,
+				Severity: SeverityWarning,
+				Rule:     RuleMissingIfExists,
+				Message:  "DROP schema statement without IF EXISTS",
+			})
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,134 @@
+package neo4go
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		expect      []ValidationIssue
+		expectError error
+	}{
+		{
+			name: "clean migrations",
+			files: map[string]string{
+				"001_init.cypher": "-- +neo4go Up\nCREATE CONSTRAINT c1 IF NOT EXISTS FOR (n:A) REQUIRE n.id IS UNIQUE;\n\n-- +neo4go Down\nDROP CONSTRAINT c1 IF EXISTS;\n",
+				"002_data.cypher": "-- +neo4go Up\nMATCH (n:A) SET n.x = 1;\n\n-- +neo4go Down\nMATCH (n:A) REMOVE n.x;\n",
+				"README.md":       "not a migration",
+			},
+		},
+		{
+			name: "duplicate versions",
+			files: map[string]string{
+				"001_init.cypher":  "-- +neo4go Up\nMATCH (n) RETURN n;\n-- +neo4go Down\nMATCH (n) RETURN n;\n",
+				"001_other.cypher": "-- +neo4go Up\nMATCH (n) RETURN n;\n-- +neo4go Down\nMATCH (n) RETURN n;\n",
+			},
+			expect: []ValidationIssue{
+				{File: "001_other.cypher", Severity: SeverityError, Rule: RuleDuplicateVersion, Message: "version 1 is also used by 001_init.cypher"},
+			},
+		},
+		{
+			name: "mis-named file",
+			files: map[string]string{
+				"001_init.cypher":  "-- +neo4go Up\nMATCH (n) RETURN n;\n-- +neo4go Down\nMATCH (n) RETURN n;\n",
+				"add_users.cypher": "-- +neo4go Up\nMATCH (n) RETURN n;\n-- +neo4go Down\nMATCH (n) RETURN n;\n",
+			},
+			expect: []ValidationIssue{
+				{File: "add_users.cypher", Severity: SeverityError, Rule: RuleFileName, Message: "file name does not match {version}_{name}.cypher and is ignored"},
+			},
+		},
+		{
+			name: "split and nested files",
+			files: map[string]string{
+				"001_init.cypher":                  "-- +neo4go Up\nMATCH (n) RETURN n;\n-- +neo4go Down\nMATCH (n) RETURN n;\n",
+				"2026/q1/002_users.up.cypher":      "CREATE INDEX users IF NOT EXISTS FOR (u:User) ON (u.id);\n",
+				"2026/q1/002_users.down.cypher":    "\nDROP INDEX users;\n",
+				"billing/003_invoices.down.cypher": "DROP INDEX invoices IF EXISTS;\n",
+			},
+			expect: []ValidationIssue{
+				{File: "2026/q1/002_users.down.cypher", Line: 2, Severity: SeverityWarning, Rule: RuleMissingIfExists, Message: "DROP schema statement without IF EXISTS"},
+				{File: "billing/003_invoices.down.cypher", Severity: SeverityError, Rule: RuleFileName, Message: "down file has no matching .up.cypher file"},
+			},
+		},
+		{
+			name: "empty down section",
+			files: map[string]string{
+				"001_init.cypher": "-- +neo4go Up\nMATCH (n) RETURN n;\n-- +neo4go Down\n\n",
+			},
+			expect: []ValidationIssue{
+				{File: "001_init.cypher", Severity: SeverityError, Rule: RuleEmptySection, Message: "Down section is missing or empty"},
+			},
+		},
+		{
+			name: "down section that cannot be derived",
+			files: map[string]string{
+				"001_init.cypher": "-- +neo4go Up\nCREATE INDEX i1 IF NOT EXISTS FOR (n:A) ON (n.x);\nMATCH (n:A) SET n.x = 1;\n",
+			},
+			expect: []ValidationIssue{
+				{File: "001_init.cypher", Severity: SeverityError, Rule: RuleEmptySection, Message: "migration missing down statement: statement at line 3 is not a named CREATE INDEX or CREATE CONSTRAINT, so the Down section cannot be derived"},
+			},
+		},
+		{
+			name: "mixed statements and missing IF EXISTS",
+			files: map[string]string{
+				"001_init.cypher": "-- +neo4go Up\nCREATE INDEX i1 FOR (n:A) ON (n.x);\nMATCH (n:A) SET n.x = 1;\n\n-- +neo4go Down\nDROP INDEX i1;\n",
+			},
+			expect: []ValidationIssue{
+				{File: "001_init.cypher", Line: 2, Severity: SeverityWarning, Rule: RuleMissingIfExists, Message: "CREATE schema statement without IF NOT EXISTS"},
+				{File: "001_init.cypher", Line: 3, Severity: SeverityWarning, Rule: RuleMixedStatements, Message: "up section mixes schema and data statements, which run in separate transactions"},
+				{File: "001_init.cypher", Line: 6, Severity: SeverityWarning, Rule: RuleMissingIfExists, Message: "DROP schema statement without IF EXISTS"},
+			},
+		},
+		{
+			name: "mixed statements allowed with explicit transaction mode",
+			files: map[string]string{
+				"001_init.cypher": "-- +neo4go TransactionPerStatement\n-- +neo4go Up\nCREATE OR REPLACE INDEX i1 FOR (n:A) ON (n.x);\nMATCH (n:A) SET n.x = 1;\n\n-- +neo4go Down\nDROP INDEX i1 IF EXISTS;\n",
+			},
+		},
+		{
+			name: "unbalanced quote",
+			files: map[string]string{
+				"001_init.cypher": "-- +neo4go Up\nMATCH (n) SET n.name = 'oops;\n-- +neo4go Down\nMATCH (n) REMOVE n.name;\n",
+			},
+			expect: []ValidationIssue{
+				{File: "001_init.cypher", Severity: SeverityError, Rule: RuleUnbalancedQuote, Message: "invalid migration file: unterminated string literal at line 2"},
+			},
+		},
+		{
+			name:        "no migrations",
+			files:       map[string]string{"README.md": "nothing here"},
+			expectError: ErrNoMigrations,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem := fstest.MapFS{}
+			for name, content := range tt.files {
+				filesystem[name] = &fstest.MapFile{Data: []byte(content)}
+			}
+
+			issues, err := Validate(filesystem)
+
+			if tt.expectError != nil {
+				if !errors.Is(err, tt.expectError) {
+					t.Fatalf("expected error %v, got %v", tt.expectError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(issues, tt.expect) {
+				t.Errorf("expected issues:\n%+v\ngot:\n%+v", tt.expect, issues)
+			}
+		})
+	}
+}